@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure interface compliance
+var _ datasource.DataSource = &AccountDataSource{}
+
+func NewAccountDataSource() datasource.DataSource {
+	return &AccountDataSource{}
+}
+
+// AccountDataSource defines the data source implementation
+type AccountDataSource struct {
+	client *Client
+}
+
+// AccountDataSourceModel describes the data source data model
+type AccountDataSourceModel struct {
+	ID                types.String  `tfsdk:"id"`
+	Email             types.String  `tfsdk:"email"`
+	CurrentSpendPerHr types.Float64 `tfsdk:"current_spend_per_hr"`
+}
+
+func (d *AccountDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account"
+}
+
+func (d *AccountDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the account the API key belongs to. Useful to check credentials without creating any resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the account.",
+				Computed:    true,
+			},
+			"email": schema.StringAttribute{
+				Description: "The email address of the account. Null when RunPod does not report it.",
+				Computed:    true,
+			},
+			"current_spend_per_hr": schema.Float64Attribute{
+				Description: "What the account's running resources currently cost per hour in USD.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *AccountDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AccountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, d.client)
+
+	tflog.Debug(ctx, "Reading account")
+
+	myself, err := d.client.GetMyself(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to read account: %s", err))
+		return
+	}
+
+	data := AccountDataSourceModel{
+		ID:                types.StringValue(myself.ID),
+		Email:             optionalString(myself.Email),
+		CurrentSpendPerHr: types.Float64Value(myself.CurrentSpendPerHr),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
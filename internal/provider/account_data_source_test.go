@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAccountDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "runpod_account" "current" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.runpod_account.current", "id"),
+					resource.TestCheckResourceAttrSet("data.runpod_account.current", "current_spend_per_hr"),
+				),
+			},
+		},
+	})
+}
+
+func TestGetMyself(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		if query != getMyselfQuery {
+			t.Fatalf("unexpected query: %s", query)
+		}
+		return json.RawMessage(`{"myself":{"id":"user-1","email":"ops@example.com","currentSpendPerHr":1.25}}`), nil
+	})
+
+	myself, err := client.GetMyself(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if myself.ID != "user-1" || myself.Email != "ops@example.com" || myself.CurrentSpendPerHr != 1.25 {
+		t.Errorf("unexpected account: %+v", myself)
+	}
+
+	client = newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"myself":null}`), nil
+	})
+	if _, err := client.GetMyself(context.Background()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound without an account, got %v", err)
+	}
+}
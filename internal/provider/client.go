@@ -2,34 +2,278 @@ package provider
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-const defaultBaseURL = "https://api.runpod.io/graphql"
+const (
+	defaultAPIHost     = "https://api.runpod.io"
+	defaultGraphQLPath = "/graphql"
+	defaultBaseURL     = defaultAPIHost + defaultGraphQLPath
+
+	defaultRetryBaseDelay = 2 * time.Second
+)
 
 // Client handles communication with the RunPod GraphQL API
 type Client struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
-	mu         sync.Mutex // ensures sequential API calls
+	baseURL         string
+	apiKey          string
+	httpClient      *http.Client
+	maxRetries      int                   // retries after the first attempt of a rate-limited or unavailable request
+	retryDelays     map[int]time.Duration // backoff base delay by retryable status code
+	rand            *rand.Rand            // jitters retry delays; guarded by mu
+	requests        chan struct{}         // semaphore bounding the API requests in flight
+	headers         map[string]string
+	terminator      *terminateBatcher
+	reader          *readBatcher
+	budget          *costBudget // caps the estimated hourly cost created per run; nil when uncapped
+	gpuTypes        *gpuTypesCache
+	templates       *templatesCache
+	logMaxBytes     int              // truncation limit of logged request and response bodies; 0 logs them in full
+	warningPatterns []*regexp.Regexp // GraphQL error messages treated as warnings instead of failures
+	warnings        *graphQLWarnings
+	strictPortLimit bool        // fail plans exposing more than maxExposedPorts ports instead of warning
+	gql             graphQLDoer // runs the queries of the API methods; the client itself by default
+	mu              sync.Mutex  // guards rand
+}
+
+// graphQLDoer runs a GraphQL query and returns its data. Client implements it
+// over HTTP; tests can substitute a fake to return canned responses.
+type graphQLDoer interface {
+	Do(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error)
+}
+
+// ClientOption configures optional Client settings
+type ClientOption func(*Client)
+
+// WithIdleConnTimeout sets how long an idle keep-alive connection is kept
+// before it is closed and a fresh one is dialled
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Transport = newTransport(d)
+	}
+}
+
+// WithHeaders adds custom headers to every API request. Headers the client
+// sets itself, such as Content-Type, cannot be overridden.
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) {
+		c.headers = headers
+	}
+}
+
+// WithRetryBaseDelay sets the base of the exponential backoff used when the
+// API responds with statusCode, which must be 429 or 503
+func WithRetryBaseDelay(statusCode int, d time.Duration) ClientOption {
+	return func(c *Client) {
+		if _, ok := c.retryDelays[statusCode]; ok {
+			c.retryDelays[statusCode] = d
+		}
+	}
+}
+
+// WithRequestTimeout sets how long a single HTTP request to the API may take
+func WithRequestTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Timeout = d
+	}
+}
+
+// WithMaxConcurrentRequests sets how many API requests may be in flight at
+// once. Further requests wait for one to finish.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.requests = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithMaxRetries sets how many times a rate-limited or unavailable request is
+// retried. 0 disables retries.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// WithMaxTotalCostPerHr caps the estimated hourly cost of the pods the client
+// creates over its lifetime, which is a single Terraform run
+func WithMaxTotalCostPerHr(limit float64) ClientOption {
+	return func(c *Client) {
+		c.budget = &costBudget{limit: limit}
+	}
+}
+
+// WithDebugLogMaxBytes sets how much of each request and response body is
+// logged at debug level. 0 logs bodies in full.
+func WithDebugLogMaxBytes(n int) ClientOption {
+	return func(c *Client) {
+		c.logMaxBytes = n
+	}
+}
+
+// WithGraphQLWarningPatterns treats GraphQL errors whose message matches any
+// of patterns as warnings: they are logged and collected for TakeWarnings, and
+// the request succeeds with whatever data the API returned
+func WithGraphQLWarningPatterns(patterns []*regexp.Regexp) ClientOption {
+	return func(c *Client) {
+		c.warningPatterns = patterns
+	}
+}
+
+// WithStrictPortLimit makes exposing more than maxExposedPorts ports a plan
+// error instead of a warning
+func WithStrictPortLimit() ClientOption {
+	return func(c *Client) {
+		c.strictPortLimit = true
+	}
+}
+
+// WithEndpoint sets the full URL of the GraphQL endpoint
+func WithEndpoint(endpoint string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = endpoint
+	}
+}
+
+// graphQLEndpoint joins a host-only base URL and a GraphQL path, and checks
+// the result is a valid absolute http(s) URL
+func graphQLEndpoint(host, graphQLPath string) (string, error) {
+	base, err := url.Parse(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid API host %q: %w", host, err)
+	}
+	if (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return "", fmt.Errorf("API host %q must be an absolute http or https URL", host)
+	}
+	if strings.Trim(base.Path, "/") != "" || base.RawQuery != "" {
+		return "", fmt.Errorf("API host %q must not include a path or query", host)
+	}
+	if !strings.HasPrefix(graphQLPath, "/") {
+		return "", fmt.Errorf("GraphQL path %q must start with /", graphQLPath)
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(host, "/") + graphQLPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid GraphQL endpoint: %w", err)
+	}
+	if endpoint.RawQuery != "" || endpoint.Fragment != "" {
+		return "", fmt.Errorf("GraphQL path %q must not include a query or fragment", graphQLPath)
+	}
+	return endpoint.String(), nil
 }
 
 // NewClient creates a new RunPod API client
-func NewClient(apiKey string) *Client {
-	return &Client{
+func NewClient(apiKey string, opts ...ClientOption) *Client {
+	c := &Client{
 		baseURL: defaultBaseURL,
 		apiKey:  apiKey,
 		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
+			Timeout:   defaultRequestTimeout,
+			Transport: newTransport(defaultIdleConnTimeout),
 		},
+		maxRetries:  defaultMaxRetries,
+		logMaxBytes: defaultDebugLogMaxBytes,
+		retryDelays: map[int]time.Duration{
+			http.StatusTooManyRequests:    defaultRetryBaseDelay,
+			http.StatusServiceUnavailable: defaultRetryBaseDelay,
+		},
+		rand:     rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 0)),
+		requests: make(chan struct{}, defaultMaxConcurrentRequests),
+	}
+	c.terminator = &terminateBatcher{client: c, window: terminateBatchWindow}
+	c.reader = &readBatcher{client: c, window: readBatchWindow}
+	c.gpuTypes = &gpuTypesCache{}
+	c.templates = &templatesCache{}
+	c.warnings = &graphQLWarnings{}
+	c.gql = c
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+const defaultIdleConnTimeout = 30 * time.Second
+
+const (
+	defaultRequestTimeout        = 60 * time.Second
+	defaultMaxRetries            = 4
+	defaultMaxConcurrentRequests = 4
+)
+
+// maxRetriesLimit is the most retries max_retries may set, and maxRetryDelay
+// caps the exponential backoff between them
+const (
+	maxRetriesLimit = 10
+	maxRetryDelay   = 5 * time.Minute
+)
+
+const defaultDebugLogMaxBytes = 4096
+
+// graphQLOperationRegexp matches the operation type and name at the start of
+// a GraphQL document, e.g. "mutation PodResume"
+var graphQLOperationRegexp = regexp.MustCompile(`^\s*(?:query|mutation)\s+(\w+)`)
+
+// graphQLOperationName returns the name of the operation in query, or
+// "anonymous" for an unnamed one
+func graphQLOperationName(query string) string {
+	if m := graphQLOperationRegexp.FindStringSubmatch(query); m != nil {
+		return m[1]
 	}
+	return "anonymous"
+}
+
+// truncateForLog returns body as a string cut to at most maxBytes, marking
+// any truncation. A maxBytes of 0 disables truncation.
+func truncateForLog(body []byte, maxBytes int) string {
+	if maxBytes <= 0 || len(body) <= maxBytes {
+		return string(body)
+	}
+	// Don't split a multi-byte character
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... (truncated, %d bytes total)", body[:cut], len(body))
+}
+
+// newTransport returns a transport that recycles idle connections before the
+// API silently drops them, and health-checks HTTP/2 connections with pings
+func newTransport(idleConnTimeout time.Duration) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.IdleConnTimeout = idleConnTimeout
+	transport.HTTP2 = &http.HTTP2Config{
+		SendPingTimeout: idleConnTimeout / 2,
+		PingTimeout:     15 * time.Second,
+	}
+	return transport
+}
+
+// HTTPError is returned when the API responds with an error status code
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
 }
 
 // GraphQL request/response types
@@ -44,12 +288,327 @@ type graphQLResponse struct {
 }
 
 type graphQLError struct {
-	Message string `json:"message"`
+	Message    string        `json:"message"`
+	Path       []interface{} `json:"path,omitempty"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
 }
 
-func (c *Client) doRequest(query string, variables map[string]interface{}) (json.RawMessage, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// ErrorCode classifies a GraphQL error returned by the API
+type ErrorCode string
+
+const (
+	ErrorCodeUnknown         ErrorCode = "UNKNOWN"
+	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
+	ErrorCodeConflict        ErrorCode = "CONFLICT"
+	ErrorCodeUnauthenticated ErrorCode = "UNAUTHENTICATED"
+)
+
+// ErrNotFound is matched by errors.Is for every error reporting that a
+// resource does not exist, whether the API returned a GraphQL error or no
+// object
+var ErrNotFound = errors.New("not found")
+
+// APIError is a GraphQL error returned by the API
+type APIError struct {
+	Message string
+	Path    []interface{}
+	Code    ErrorCode // from the error's extensions, or inferred from its message
+}
+
+func (e *APIError) Error() string {
+	return "GraphQL error: " + e.Message
+}
+
+// Is makes errors.Is(err, ErrNotFound) match not found API errors
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.Code == ErrorCodeNotFound
+}
+
+// newAPIError converts a GraphQL error from a response to an APIError
+func newAPIError(gqlErr graphQLError) *APIError {
+	return &APIError{
+		Message: gqlErr.Message,
+		Path:    gqlErr.Path,
+		Code:    apiErrorCode(gqlErr),
+	}
+}
+
+// apiErrorCode returns the code of a GraphQL error. The API rarely sets an
+// extension code, so most codes are inferred from the message.
+func apiErrorCode(gqlErr graphQLError) ErrorCode {
+	switch strings.ToUpper(gqlErr.Extensions.Code) {
+	case "NOT_FOUND":
+		return ErrorCodeNotFound
+	case "CONFLICT":
+		return ErrorCodeConflict
+	case "UNAUTHENTICATED", "FORBIDDEN":
+		return ErrorCodeUnauthenticated
+	}
+
+	msg := strings.ToLower(gqlErr.Message)
+	switch {
+	case strings.Contains(msg, "not found") || strings.Contains(msg, "does not exist"):
+		return ErrorCodeNotFound
+	case strings.Contains(msg, "conflict"):
+		return ErrorCodeConflict
+	case strings.Contains(msg, "unauthorized") || strings.Contains(msg, "unauthenticated"):
+		return ErrorCodeUnauthenticated
+	}
+	return ErrorCodeUnknown
+}
+
+// IsNotFound reports whether err indicates the requested resource does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// isConflictError reports whether err indicates the pod was modified concurrently
+func isConflictError(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusConflict {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == ErrorCodeConflict {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "conflict")
+}
+
+// isCapacityError reports whether err indicates a deploy failed because no
+// machine had enough free GPUs
+func isCapacityError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "no longer any instances available") ||
+		strings.Contains(msg, "not enough") ||
+		strings.Contains(msg, "insufficient") ||
+		strings.Contains(msg, "no instances available")
+}
+
+// isVolumeNotReadyError reports whether err indicates an attached network
+// volume is still being provisioned
+func isVolumeNotReadyError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "volume") &&
+		(strings.Contains(msg, "not ready") || strings.Contains(msg, "not available"))
+}
+
+// ErrTemplateNotFound is returned by GetTemplate when the account has no
+// template with the ID
+var ErrTemplateNotFound = fmt.Errorf("template %w", ErrNotFound)
+
+// ErrPublicIPUnavailable is returned by CreatePod when a public IP was
+// requested but the data center cannot provide one
+var ErrPublicIPUnavailable = errors.New("public IP not available")
+
+// ErrCUDAVersionUnsupported is returned by CreatePod when no machine's driver
+// supports the allowed CUDA versions
+var ErrCUDAVersionUnsupported = errors.New("no machine supports the allowed CUDA versions")
+
+// ErrTooManyPorts is returned by CreatePod when the pod exposes more ports
+// than RunPod allows
+var ErrTooManyPorts = errors.New("too many exposed ports")
+
+// isTooManyPortsError reports whether err indicates a deploy was rejected for
+// exposing too many ports
+func isTooManyPortsError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	if !strings.Contains(msg, "port") {
+		return false
+	}
+	for _, reason := range []string{"too many", "maximum", "exceed", "limit"} {
+		if strings.Contains(msg, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// knownCUDAVersions are the CUDA versions RunPod accepts in
+// allowedCudaVersions
+var knownCUDAVersions = []string{"11.8", "12.0", "12.1", "12.2", "12.3", "12.4", "12.5", "12.6", "12.7", "12.8"}
+
+// isCUDAVersionError reports whether a deploy error or pod status message
+// indicates the host driver does not support the requested CUDA version
+func isCUDAVersionError(msg string) bool {
+	msg = strings.ToLower(msg)
+	if !strings.Contains(msg, "cuda") {
+		return false
+	}
+	for _, reason := range []string{"driver", "not supported", "unsupported", "incompatible", "insufficient", "no longer any instances"} {
+		if strings.Contains(msg, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPublicIPUnavailableError reports whether err indicates a deploy failed
+// because no public IP could be assigned
+func isPublicIPUnavailableError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "public ip") &&
+		(strings.Contains(msg, "not available") || strings.Contains(msg, "unavailable"))
+}
+
+// isImagePullFailure reports whether a deploy error or pod status message
+// indicates the container image could not be pulled
+func isImagePullFailure(msg string) bool {
+	msg = strings.ToLower(msg)
+	if !strings.Contains(msg, "pull") && !strings.Contains(msg, "manifest") {
+		return false
+	}
+	for _, reason := range []string{"denied", "unauthorized", "not found", "unknown", "failed", "error"} {
+		if strings.Contains(msg, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRegistryAuthFailure reports whether a deploy error or pod status message
+// indicates the registry rejected the pod's credentials, e.g. because a cloud
+// registry token expired
+func isRegistryAuthFailure(msg string) bool {
+	msg = strings.ToLower(msg)
+	if !strings.Contains(msg, "registry") && !strings.Contains(msg, "pull") {
+		return false
+	}
+	for _, reason := range []string{"unauthorized", "authentication required", "token expired", "expired token", "no basic auth credentials", "denied"} {
+		if strings.Contains(msg, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// Do sends a GraphQL request and returns its data. On a GraphQL error the
+// data, which the API may still have partly filled in, is returned with an
+// *APIError, so callers can decide whether it is usable. Several GraphQL
+// errors are returned joined, in order; apiErrors splits them again.
+func (c *Client) Do(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	gqlResp, err := c.execute(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	switch errs := c.fatalErrors(ctx, gqlResp.Errors); len(errs) {
+	case 0:
+		return gqlResp.Data, nil
+	case 1:
+		return gqlResp.Data, newAPIError(errs[0])
+	default:
+		apiErrs := make([]error, len(errs))
+		for i, gqlErr := range errs {
+			apiErrs[i] = newAPIError(gqlErr)
+		}
+		return gqlResp.Data, errors.Join(apiErrs...)
+	}
+}
+
+// apiErrors returns the GraphQL errors in an error returned by Do, or nil
+// when the request failed for another reason
+func apiErrors(err error) []*APIError {
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+
+	var apiErrs []*APIError
+	for _, err := range errs {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			apiErrs = append(apiErrs, apiErr)
+		}
+	}
+	return apiErrs
+}
+
+// partialSuccess returns nil when a mutation failed with a GraphQL error but
+// still returned its object under field, recording the error as a warning
+// instead, and err otherwise. The API reports some warnings this way next
+// to a completed operation.
+func (c *Client) partialSuccess(ctx context.Context, data json.RawMessage, err error, field string) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	var result map[string]json.RawMessage
+	if json.Unmarshal(data, &result) != nil {
+		return err
+	}
+	if object, ok := result[field]; !ok || len(object) == 0 || string(object) == "null" {
+		return err
+	}
+
+	tflog.Warn(ctx, "GraphQL error returned with data, treated as a warning", map[string]interface{}{
+		"field":   field,
+		"message": apiErr.Message,
+	})
+	c.warnings.add(apiErr.Message)
+	return nil
+}
+
+// fatalErrors returns the GraphQL errors that fail a request. Errors matching
+// the client's warning patterns are logged and recorded as warnings instead.
+func (c *Client) fatalErrors(ctx context.Context, errs []graphQLError) []graphQLError {
+	if len(c.warningPatterns) == 0 {
+		return errs
+	}
+
+	var fatal []graphQLError
+	for _, gqlErr := range errs {
+		if !slices.ContainsFunc(c.warningPatterns, func(re *regexp.Regexp) bool { return re.MatchString(gqlErr.Message) }) {
+			fatal = append(fatal, gqlErr)
+			continue
+		}
+		tflog.Warn(ctx, "GraphQL error treated as a warning", map[string]interface{}{
+			"message": gqlErr.Message,
+		})
+		c.warnings.add(gqlErr.Message)
+	}
+	return fatal
+}
+
+// graphQLWarnings collects the GraphQL errors treated as warnings until they
+// are reported
+type graphQLWarnings struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (w *graphQLWarnings) add(message string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !slices.Contains(w.messages, message) {
+		w.messages = append(w.messages, message)
+	}
+}
+
+// TakeWarnings returns the GraphQL errors treated as warnings since the last
+// call, and clears them
+func (c *Client) TakeWarnings() []string {
+	c.warnings.mu.Lock()
+	defer c.warnings.mu.Unlock()
+	messages := c.warnings.messages
+	c.warnings.messages = nil
+	return messages
+}
+
+// execute sends a GraphQL request, retrying on rate limits, and returns the
+// raw response including any GraphQL errors
+func (c *Client) execute(ctx context.Context, query string, variables map[string]interface{}) (*graphQLResponse, error) {
+	// The slot is held through retries, so a rate-limited request backing
+	// off does not make room for another one
+	select {
+	case c.requests <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-c.requests }()
 
 	reqBody := graphQLRequest{
 		Query:     query,
@@ -61,18 +620,35 @@ func (c *Client) doRequest(query string, variables map[string]interface{}) (json
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	// Retry with exponential backoff for rate limiting
-	maxRetries := 5
-	baseDelay := 2 * time.Second
+	// The key is only sent in the Authorization header, which is never
+	// logged, but is masked in case a variable or response ever contains it
+	if c.apiKey != "" {
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, c.apiKey)
+		ctx = tflog.MaskMessageStrings(ctx, c.apiKey)
+	}
+	ctx = tflog.SetField(ctx, "operation", graphQLOperationName(query))
 
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		url := fmt.Sprintf("%s?api_key=%s", c.baseURL, c.apiKey)
-		req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	// The query document is a constant, listed by runpod_debug_queries, so
+	// only the variables are logged
+	jsonVariables, _ := json.Marshal(variables)
+	tflog.Debug(ctx, "Sending GraphQL request", map[string]interface{}{
+		"variables": truncateForLog(jsonVariables, c.logMaxBytes),
+	})
+
+	// Retry with exponential backoff for rate limiting
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL, bytes.NewBuffer(jsonBody))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
 
+		for name, value := range c.headers {
+			req.Header.Set(name, value)
+		}
 		req.Header.Set("Content-Type", "application/json")
+		// The key is sent as a header rather than in the URL, which proxies
+		// and HTTP tracing tend to log
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
@@ -85,18 +661,27 @@ func (c *Client) doRequest(query string, variables map[string]interface{}) (json
 			return nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 
-		// Retry on 429 Too Many Requests or 503 Service Unavailable
-		if resp.StatusCode == http.StatusTooManyRequests ||
-			resp.StatusCode == http.StatusServiceUnavailable {
-			if attempt < maxRetries-1 {
-				delay := baseDelay * time.Duration(1<<attempt)
-				time.Sleep(delay)
+		tflog.Debug(ctx, "Received GraphQL response", map[string]interface{}{
+			"status": resp.StatusCode,
+			"body":   truncateForLog(respBody, c.logMaxBytes),
+		})
+
+		// Retry on 429 Too Many Requests or 503 Service Unavailable, each
+		// with its own backoff base. The wait is jittered so that requests
+		// rate-limited together do not retry together.
+		if delay, ok := c.retryDelay(resp.StatusCode, attempt); ok {
+			if attempt < c.maxRetries {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(c.jitter(delay)):
+				}
 				continue
 			}
 		}
 
 		if resp.StatusCode >= 400 {
-			return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
+			return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(respBody)}
 		}
 
 		var gqlResp graphQLResponse
@@ -104,41 +689,104 @@ func (c *Client) doRequest(query string, variables map[string]interface{}) (json
 			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 
-		if len(gqlResp.Errors) > 0 {
-			return nil, fmt.Errorf("GraphQL error: %s", gqlResp.Errors[0].Message)
-		}
-
-		return gqlResp.Data, nil
+		return &gqlResp, nil
 	}
 
 	return nil, fmt.Errorf("max retries exceeded")
 }
 
+// retryDelay returns how long to wait before retrying a request that failed
+// with statusCode on the given attempt, and whether the status is retryable
+func (c *Client) retryDelay(statusCode, attempt int) (time.Duration, bool) {
+	baseDelay, ok := c.retryDelays[statusCode]
+	if !ok {
+		return 0, false
+	}
+	delay := baseDelay
+	for i := 0; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay), true
+}
+
+// jitter returns a random duration in [0, delay] ("full jitter")
+func (c *Client) jitter(delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Duration(c.rand.Int64N(int64(delay) + 1))
+}
+
+const pingQuery = `query Ping { myself { id } }`
+
 // Ping tests the API connection by querying the current user
-func (c *Client) Ping() error {
-	query := `query { myself { id } }`
-	_, err := c.doRequest(query, nil)
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.gql.Do(ctx, pingQuery, nil)
 	return err
 }
 
+// Myself represents the account the API key belongs to
+type Myself struct {
+	ID                string  `json:"id"`
+	Email             string  `json:"email"`
+	CurrentSpendPerHr float64 `json:"currentSpendPerHr"`
+}
+
+const getMyselfQuery = `query Myself {
+	myself {
+		id
+		email
+		currentSpendPerHr
+	}
+}`
+
+// GetMyself returns the account the API key belongs to, like Ping but with
+// the account's email and current spend
+func (c *Client) GetMyself(ctx context.Context) (*Myself, error) {
+	data, err := c.gql.Do(ctx, getMyselfQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Myself *Myself `json:"myself"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal myself response: %w", err)
+	}
+
+	if result.Myself == nil {
+		return nil, fmt.Errorf("account %w", ErrNotFound)
+	}
+
+	return result.Myself, nil
+}
+
 // Pod represents a RunPod pod
 type Pod struct {
-	ID                string   `json:"id"`
-	Name              string   `json:"name"`
-	ImageName         string   `json:"imageName"`
-	GpuTypeID         string   `json:"gpuTypeId"`
-	GpuCount          int      `json:"gpuCount"`
-	VolumeInGb        int      `json:"volumeInGb"`
-	ContainerDiskInGb int      `json:"containerDiskInGb"`
-	DesiredStatus     string   `json:"desiredStatus"`
-	CloudType         string   `json:"cloudType"`
-	Ports             string   `json:"ports"`
-	VolumeMountPath   string   `json:"volumeMountPath"`
-	DockerArgs        string   `json:"dockerArgs"`
-	Env               EnvVars  `json:"env"`
-	MachineID         string   `json:"machineId"`
-	Machine           *Machine `json:"machine"`
-	Runtime           *Runtime `json:"runtime"`
+	ID                string         `json:"id"`
+	Name              string         `json:"name"`
+	ImageName         string         `json:"imageName"`
+	GpuTypeID         string         `json:"gpuTypeId"`
+	GpuCount          int            `json:"gpuCount"`
+	VolumeInGb        int            `json:"volumeInGb"`
+	ContainerDiskInGb int            `json:"containerDiskInGb"`
+	DesiredStatus     string         `json:"desiredStatus"`
+	CostPerHr         float64        `json:"costPerHr"`
+	LastStatusChange  string         `json:"lastStatusChange"`
+	CloudType         string         `json:"cloudType"`
+	Ports             string         `json:"ports"`
+	VolumeMountPath   string         `json:"volumeMountPath"`
+	DockerArgs        string         `json:"dockerArgs"`
+	Env               EnvVars        `json:"env"`
+	NetworkVolumeID   string         `json:"networkVolumeId"`
+	NetworkVolume     *NetworkVolume `json:"networkVolume"`
+	RegistryAuthID    string         `json:"containerRegistryAuthId"`
+	MachineID         string         `json:"machineId"`
+	Machine           *Machine       `json:"machine"`
+	Runtime           *Runtime       `json:"runtime"`
 }
 
 type EnvVar struct {
@@ -151,6 +799,12 @@ type EnvVar struct {
 type EnvVars []EnvVar
 
 func (e *EnvVars) UnmarshalJSON(data []byte) error {
+	// Pods without env return null
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*e = EnvVars{}
+		return nil
+	}
+
 	// Try to unmarshal as string array first (API response format)
 	var stringArray []string
 	if err := json.Unmarshal(data, &stringArray); err == nil {
@@ -176,13 +830,22 @@ func (e *EnvVars) UnmarshalJSON(data []byte) error {
 }
 
 type Machine struct {
-	PodHostID string `json:"podHostId"`
-	GpuTypeID string `json:"gpuTypeId"`
+	PodHostID   string `json:"podHostId"`
+	GpuTypeID   string `json:"gpuTypeId"`
+	SecureCloud bool   `json:"secureCloud"`
+	Location    string `json:"location"`
 }
 
 type Runtime struct {
-	UptimeInSeconds int     `json:"uptimeInSeconds"`
-	Ports           []Port  `json:"ports"`
+	UptimeInSeconds int               `json:"uptimeInSeconds"`
+	Ports           []Port            `json:"ports"`
+	Container       *ContainerRuntime `json:"container"`
+}
+
+// ContainerRuntime holds the container's current resource utilization
+type ContainerRuntime struct {
+	CPUPercent    int `json:"cpuPercent"`
+	MemoryPercent int `json:"memoryPercent"`
 }
 
 type Port struct {
@@ -195,77 +858,174 @@ type Port struct {
 
 // PodInput represents the input for creating a pod
 type PodInput struct {
-	Name              string `json:"name"`
-	ImageName         string `json:"imageName"`
-	GpuTypeID         string `json:"gpuTypeId"`
-	GpuCount          int    `json:"gpuCount"`
-	VolumeInGb        int      `json:"volumeInGb"`
-	ContainerDiskInGb int      `json:"containerDiskInGb"`
-	CloudType         string   `json:"cloudType,omitempty"`
-	Ports             string   `json:"ports,omitempty"`
-	VolumeMountPath   string   `json:"volumeMountPath,omitempty"`
-	DockerArgs        string   `json:"dockerArgs,omitempty"`
-	Env               []EnvVar `json:"env,omitempty"`
-	MinVcpuCount      int      `json:"minVcpuCount,omitempty"`
-	MinMemoryInGb     int      `json:"minMemoryInGb,omitempty"`
-	NetworkVolumeID   string   `json:"networkVolumeId,omitempty"`
-	TemplateID        string   `json:"templateId,omitempty"`
-	DataCenterID      string   `json:"dataCenterId,omitempty"`
-	SupportPublicIP   bool     `json:"supportPublicIp,omitempty"`
-	StartSSH          bool     `json:"startSsh,omitempty"`
+	Name                string   `json:"name"`
+	ImageName           string   `json:"imageName"`
+	GpuTypeID           string   `json:"gpuTypeId"`
+	GpuTypeIDs          []string `json:"gpuTypeIds,omitempty"` // fallback GPU types, tried in order after GpuTypeID
+	GpuCount            int      `json:"gpuCount"`
+	VolumeInGb          int      `json:"volumeInGb"`
+	ContainerDiskInGb   int      `json:"containerDiskInGb"`
+	CloudType           string   `json:"cloudType,omitempty"`
+	Ports               string   `json:"ports,omitempty"`
+	VolumeMountPath     string   `json:"volumeMountPath,omitempty"`
+	DockerArgs          string   `json:"dockerArgs,omitempty"`
+	Env                 []EnvVar `json:"env,omitempty"`
+	MinVcpuCount        int      `json:"minVcpuCount,omitempty"`
+	MinMemoryInGb       int      `json:"minMemoryInGb,omitempty"`
+	NetworkVolumeID     string   `json:"networkVolumeId,omitempty"`
+	TemplateID          string   `json:"templateId,omitempty"`
+	RegistryAuthID      string   `json:"containerRegistryAuthId,omitempty"`
+	DataCenterID        string   `json:"dataCenterId,omitempty"`
+	AllowedCudaVersions []string `json:"allowedCudaVersions,omitempty"`
+	SupportPublicIP     bool     `json:"supportPublicIp,omitempty"`
+	StartSSH            bool     `json:"startSsh,omitempty"`
+	BidPerGpu           float64  `json:"bidPerGpu,omitempty"` // hourly bid per GPU of an interruptible pod; 0 for on demand
 }
 
-// CreatePod creates a new on-demand pod
-func (c *Client) CreatePod(input *PodInput) (*Pod, error) {
-	query := `mutation PodFindAndDeployOnDemand($input: PodFindAndDeployOnDemandInput!) {
-		podFindAndDeployOnDemand(input: $input) {
-			id
-			name
-			imageName
-			gpuCount
-			volumeInGb
-			containerDiskInGb
-			desiredStatus
-			ports
-			volumeMountPath
-			dockerArgs
-			env
-			machineId
-			machine {
-				podHostId
-			}
+// gpuTypeIDs returns the GPU types the pod may be deployed on in order of
+// preference: GpuTypeID, then GpuTypeIDs, without duplicates
+func (in *PodInput) gpuTypeIDs() []string {
+	var ids []string
+	for _, id := range append([]string{in.GpuTypeID}, in.GpuTypeIDs...) {
+		if id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
 		}
-	}`
+	}
+	return ids
+}
 
-	// Build the input map for the GraphQL query
-	inputMap := map[string]interface{}{
-		"name":              input.Name,
-		"imageName":         input.ImageName,
-		"gpuCount":          input.GpuCount,
-		"volumeInGb":        input.VolumeInGb,
-		"containerDiskInGb": input.ContainerDiskInGb,
+const createPodMutation = `mutation PodFindAndDeployOnDemand($input: PodFindAndDeployOnDemandInput!) {
+	podFindAndDeployOnDemand(input: $input) {
+		id
+		name
+		imageName
+		gpuCount
+		volumeInGb
+		containerDiskInGb
+		desiredStatus
+		ports
+		volumeMountPath
+		dockerArgs
+		env
+		machineId
+		machine {
+			podHostId
+			gpuTypeId
+			secureCloud
+			location
+		}
 	}
+}`
 
-	// Set GPU type
-	if input.GpuTypeID != "" {
-		inputMap["gpuTypeId"] = input.GpuTypeID
+// CreatePod creates a new on-demand pod
+func (c *Client) CreatePod(ctx context.Context, input *PodInput) (*Pod, error) {
+	variables := map[string]interface{}{
+		"input": podInputMap(input),
 	}
 
-	if input.CloudType != "" {
-		inputMap["cloudType"] = input.CloudType
+	data, err := c.gql.Do(ctx, createPodMutation, variables)
+	err = c.partialSuccess(ctx, data, err, "podFindAndDeployOnDemand")
+	if err != nil {
+		return nil, createPodError(input, err)
 	}
-	if input.Ports != "" {
-		inputMap["ports"] = input.Ports
+
+	var result struct {
+		PodFindAndDeployOnDemand *Pod `json:"podFindAndDeployOnDemand"`
 	}
-	if input.VolumeMountPath != "" {
-		inputMap["volumeMountPath"] = input.VolumeMountPath
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pod response: %w", err)
 	}
-	if input.DockerArgs != "" {
-		inputMap["dockerArgs"] = input.DockerArgs
+
+	if result.PodFindAndDeployOnDemand == nil {
+		return nil, fmt.Errorf("no pod returned from API")
 	}
-	if len(input.Env) > 0 {
-		envList := make([]map[string]string, len(input.Env))
-		for i, e := range input.Env {
+
+	return result.PodFindAndDeployOnDemand, nil
+}
+
+const createInterruptiblePodMutation = `mutation PodRentInterruptable($input: PodRentInterruptableInput!) {
+	podRentInterruptable(input: $input) {
+		id
+		name
+		imageName
+		gpuCount
+		volumeInGb
+		containerDiskInGb
+		desiredStatus
+		ports
+		volumeMountPath
+		dockerArgs
+		env
+		machineId
+		machine {
+			podHostId
+			gpuTypeId
+			secureCloud
+			location
+		}
+	}
+}`
+
+// CreateInterruptiblePod creates a spot pod bidding input.BidPerGpu per GPU
+// per hour. RunPod may stop the pod when it is outbid.
+func (c *Client) CreateInterruptiblePod(ctx context.Context, input *PodInput) (*Pod, error) {
+	inputMap := podInputMap(input)
+	inputMap["bidPerGpu"] = input.BidPerGpu
+	variables := map[string]interface{}{
+		"input": inputMap,
+	}
+
+	data, err := c.gql.Do(ctx, createInterruptiblePodMutation, variables)
+	err = c.partialSuccess(ctx, data, err, "podRentInterruptable")
+	if err != nil {
+		return nil, createPodError(input, err)
+	}
+
+	var result struct {
+		PodRentInterruptable *Pod `json:"podRentInterruptable"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pod response: %w", err)
+	}
+
+	if result.PodRentInterruptable == nil {
+		return nil, fmt.Errorf("no pod returned from API")
+	}
+
+	return result.PodRentInterruptable, nil
+}
+
+// podInputMap builds the deploy mutation input shared by on-demand and
+// interruptible pods
+func podInputMap(input *PodInput) map[string]interface{} {
+	inputMap := map[string]interface{}{
+		"name":              input.Name,
+		"imageName":         input.ImageName,
+		"gpuCount":          input.GpuCount,
+		"volumeInGb":        input.VolumeInGb,
+		"containerDiskInGb": input.ContainerDiskInGb,
+	}
+
+	// Set GPU type
+	if input.GpuTypeID != "" {
+		inputMap["gpuTypeId"] = input.GpuTypeID
+	}
+
+	if input.CloudType != "" {
+		inputMap["cloudType"] = input.CloudType
+	}
+	if input.Ports != "" {
+		inputMap["ports"] = input.Ports
+	}
+	if input.VolumeMountPath != "" {
+		inputMap["volumeMountPath"] = input.VolumeMountPath
+	}
+	if input.DockerArgs != "" {
+		inputMap["dockerArgs"] = input.DockerArgs
+	}
+	if len(input.Env) > 0 {
+		envList := make([]map[string]string, len(input.Env))
+		for i, e := range input.Env {
 			envList[i] = map[string]string{"key": e.Key, "value": e.Value}
 		}
 		inputMap["env"] = envList
@@ -282,6 +1042,12 @@ func (c *Client) CreatePod(input *PodInput) (*Pod, error) {
 	if input.TemplateID != "" {
 		inputMap["templateId"] = input.TemplateID
 	}
+	if input.RegistryAuthID != "" {
+		inputMap["containerRegistryAuthId"] = input.RegistryAuthID
+	}
+	if len(input.AllowedCudaVersions) > 0 {
+		inputMap["allowedCudaVersions"] = input.AllowedCudaVersions
+	}
 	if input.DataCenterID != "" {
 		inputMap["dataCenterId"] = input.DataCenterID
 	}
@@ -291,124 +1057,524 @@ func (c *Client) CreatePod(input *PodInput) (*Pod, error) {
 	if input.StartSSH {
 		inputMap["startSsh"] = input.StartSSH
 	}
+	return inputMap
+}
 
+// createPodError wraps a failed deploy, marking the failures the resource
+// reports against a specific attribute
+func createPodError(input *PodInput, err error) error {
+	if input.SupportPublicIP && isPublicIPUnavailableError(err) {
+		return fmt.Errorf("failed to create pod: %w: %w", ErrPublicIPUnavailable, err)
+	}
+	if input.Ports != "" && isTooManyPortsError(err) {
+		return fmt.Errorf("failed to create pod: %w: %w", ErrTooManyPorts, err)
+	}
+	if len(input.AllowedCudaVersions) > 0 && isCUDAVersionError(err.Error()) {
+		return fmt.Errorf("failed to create pod: %w: %w", ErrCUDAVersionUnsupported, err)
+	}
+	return fmt.Errorf("failed to create pod: %w", err)
+}
+
+// podFields is the selection of pod fields read by GetPod and GetPods
+const podFields = `{
+	id
+	name
+	imageName
+	gpuTypeId
+	gpuCount
+	volumeInGb
+	containerDiskInGb
+	desiredStatus
+	costPerHr
+	lastStatusChange
+	ports
+	volumeMountPath
+	dockerArgs
+	env
+	containerRegistryAuthId
+	networkVolumeId
+	networkVolume {
+		id
+		size
+	}
+	machineId
+	machine {
+		podHostId
+		gpuTypeId
+		secureCloud
+		location
+	}
+	runtime {
+		uptimeInSeconds
+		ports {
+			ip
+			isIpPublic
+			privatePort
+			publicPort
+			type
+		}
+		container {
+			cpuPercent
+			memoryPercent
+		}
+	}
+}`
+
+const getPodQuery = `query Pod($input: PodFilter!) {
+	pod(input: $input) ` + podFields + `
+}`
+
+// GetPod retrieves a pod by ID
+func (c *Client) GetPod(ctx context.Context, id string) (*Pod, error) {
 	variables := map[string]interface{}{
-		"input": inputMap,
+		"input": map[string]string{
+			"podId": id,
+		},
 	}
 
-	data, err := c.doRequest(query, variables)
+	data, err := c.gql.Do(ctx, getPodQuery, variables)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create pod: %w", err)
+		return nil, err
 	}
 
 	var result struct {
-		PodFindAndDeployOnDemand *Pod `json:"podFindAndDeployOnDemand"`
+		Pod *Pod `json:"pod"`
 	}
 	if err := json.Unmarshal(data, &result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal pod response: %w", err)
 	}
 
-	if result.PodFindAndDeployOnDemand == nil {
-		return nil, fmt.Errorf("no pod returned from API")
+	if result.Pod == nil {
+		return nil, fmt.Errorf("pod %w: %s", ErrNotFound, id)
 	}
 
-	return result.PodFindAndDeployOnDemand, nil
+	return result.Pod, nil
 }
 
-// GetPod retrieves a pod by ID
-func (c *Client) GetPod(id string) (*Pod, error) {
-	query := `query Pod($input: PodFilter!) {
-		pod(input: $input) {
+const listPodsQuery = `query Pods {
+	myself {
+		pods {
 			id
 			name
 			imageName
-			gpuCount
-			volumeInGb
-			containerDiskInGb
 			desiredStatus
-			ports
-			volumeMountPath
-			dockerArgs
-			env
-			machineId
+			gpuCount
+			costPerHr
 			machine {
-				podHostId
 				gpuTypeId
 			}
 			runtime {
 				uptimeInSeconds
-				ports {
-					ip
-					isIpPublic
-					privatePort
-					publicPort
-					type
-				}
 			}
 		}
-	}`
-
-	variables := map[string]interface{}{
-		"input": map[string]string{
-			"podId": id,
-		},
 	}
+}`
 
-	data, err := c.doRequest(query, variables)
+// ListPods returns all pods in the account. The API returns every pod in a
+// single response.
+func (c *Client) ListPods(ctx context.Context) ([]Pod, error) {
+	data, err := c.gql.Do(ctx, listPodsQuery, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var result struct {
-		Pod *Pod `json:"pod"`
+		Myself struct {
+			Pods []Pod `json:"pods"`
+		} `json:"myself"`
 	}
 	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal pod response: %w", err)
-	}
-
-	if result.Pod == nil {
-		return nil, fmt.Errorf("pod not found: %s", id)
+		return nil, fmt.Errorf("failed to unmarshal pods response: %w", err)
 	}
 
-	return result.Pod, nil
+	return result.Myself.Pods, nil
 }
 
-// TerminatePod terminates (deletes) a pod
-func (c *Client) TerminatePod(id string) error {
-	query := `mutation PodTerminate($input: PodTerminateInput!) {
-		podTerminate(input: $input)
-	}`
+const terminatePodMutation = `mutation PodTerminate($input: PodTerminateInput!) {
+	podTerminate(input: $input)
+}`
 
+// TerminatePod terminates (deletes) a pod
+func (c *Client) TerminatePod(ctx context.Context, id string) error {
 	variables := map[string]interface{}{
 		"input": map[string]string{
 			"podId": id,
 		},
 	}
 
-	_, err := c.doRequest(query, variables)
+	data, err := c.gql.Do(ctx, terminatePodMutation, variables)
 	if err != nil {
 		return fmt.Errorf("failed to terminate pod: %w", err)
 	}
 
+	var result struct {
+		PodTerminate json.RawMessage `json:"podTerminate"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return terminateResultError(result.PodTerminate)
+}
+
+// terminateResultError checks the value returned by podTerminate. The
+// mutation normally returns null, but an explicit false means the API did not
+// terminate the pod even though it reported no GraphQL error.
+func terminateResultError(result json.RawMessage) error {
+	var ok *bool
+	if len(result) > 0 {
+		if err := json.Unmarshal(result, &ok); err != nil {
+			// Not a boolean, so there is no failure to report
+			return nil
+		}
+	}
+	if ok != nil && !*ok {
+		return fmt.Errorf("failed to terminate pod: API reported the pod was not terminated")
+	}
 	return nil
 }
 
-// StopPod stops a pod (without terminating it)
-func (c *Client) StopPod(id string) (*Pod, error) {
-	query := `mutation PodStop($input: PodStopInput!) {
-		podStop(input: $input) {
-			id
-			desiredStatus
+// TerminatePods terminates several pods in a single request, returning the
+// error for each pod that could not be terminated keyed by pod ID
+func (c *Client) TerminatePods(ctx context.Context, ids []string) map[string]error {
+	errs := make(map[string]error)
+	if len(ids) == 0 {
+		return errs
+	}
+
+	var params, fields strings.Builder
+	variables := make(map[string]interface{}, len(ids))
+	aliases := make(map[string]string, len(ids))
+	for i, id := range ids {
+		alias := fmt.Sprintf("t%d", i)
+		aliases[alias] = id
+		if i > 0 {
+			params.WriteString(", ")
 		}
-	}`
+		fmt.Fprintf(&params, "$%s: PodTerminateInput!", alias)
+		fmt.Fprintf(&fields, "\n\t\t%s: podTerminate(input: $%s)", alias, alias)
+		variables[alias] = map[string]string{"podId": id}
+	}
+	query := fmt.Sprintf("mutation PodTerminateBatch(%s) {%s\n\t}", params.String(), fields.String())
+
+	data, err := c.gql.Do(ctx, query, variables)
+	apiErrs := apiErrors(err)
+	if err != nil && len(apiErrs) == 0 {
+		for _, id := range ids {
+			errs[id] = fmt.Errorf("failed to terminate pod: %w", err)
+		}
+		return errs
+	}
 
+	var results map[string]json.RawMessage
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &results); err != nil {
+			for _, id := range ids {
+				errs[id] = fmt.Errorf("failed to parse response: %w", err)
+			}
+			return errs
+		}
+	}
+	for alias, result := range results {
+		if id, ok := aliases[alias]; ok {
+			if err := terminateResultError(result); err != nil {
+				errs[id] = err
+			}
+		}
+	}
+
+	for _, apiErr := range apiErrs {
+		err := fmt.Errorf("failed to terminate pod: %w", apiErr)
+
+		// Errors without a path apply to the whole batch
+		if len(apiErr.Path) == 0 {
+			for _, id := range ids {
+				errs[id] = err
+			}
+			continue
+		}
+		if alias, ok := apiErr.Path[0].(string); ok {
+			if id, ok := aliases[alias]; ok {
+				errs[id] = err
+			}
+		}
+	}
+
+	return errs
+}
+
+// terminateBatcher coalesces TerminatePod calls made within a short window
+// into a single request, so destroying many pods does not queue one request
+// per pod behind the concurrent request limit
+type terminateBatcher struct {
+	client  *Client
+	window  time.Duration
+	mu      sync.Mutex
+	pending []terminateRequest
+}
+
+type terminateRequest struct {
+	id     string
+	result chan error
+}
+
+// terminateBatchWindow is how long the first terminate in a batch waits for others to join
+const terminateBatchWindow = 50 * time.Millisecond
+
+// maxTerminateBatchSize bounds the number of pods terminated in one request
+const maxTerminateBatchSize = 25
+
+// GetPods retrieves several pods in a single request. It returns the pods
+// found and the error for each pod that could not be read, keyed by pod ID.
+func (c *Client) GetPods(ctx context.Context, ids []string) (map[string]*Pod, map[string]error) {
+	pods := make(map[string]*Pod, len(ids))
+	errs := make(map[string]error)
+	if len(ids) == 0 {
+		return pods, errs
+	}
+
+	var params, fields strings.Builder
+	variables := make(map[string]interface{}, len(ids))
+	aliases := make(map[string]string, len(ids))
+	for i, id := range ids {
+		alias := fmt.Sprintf("p%d", i)
+		aliases[alias] = id
+		if i > 0 {
+			params.WriteString(", ")
+		}
+		fmt.Fprintf(&params, "$%s: PodFilter!", alias)
+		fmt.Fprintf(&fields, "\n\t%s: pod(input: $%s) %s", alias, alias, podFields)
+		variables[alias] = map[string]string{"podId": id}
+	}
+	query := fmt.Sprintf("query PodBatch(%s) {%s\n}", params.String(), fields.String())
+
+	data, err := c.gql.Do(ctx, query, variables)
+	apiErrs := apiErrors(err)
+	if err != nil && len(apiErrs) == 0 {
+		for _, id := range ids {
+			errs[id] = err
+		}
+		return pods, errs
+	}
+
+	for _, apiErr := range apiErrs {
+		// Errors without a path apply to the whole batch
+		if len(apiErr.Path) == 0 {
+			for _, id := range ids {
+				errs[id] = apiErr
+			}
+			continue
+		}
+		if alias, ok := apiErr.Path[0].(string); ok {
+			if id, ok := aliases[alias]; ok {
+				errs[id] = apiErr
+			}
+		}
+	}
+
+	var results map[string]*Pod
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &results); err != nil {
+			for _, id := range ids {
+				errs[id] = fmt.Errorf("failed to unmarshal pod response: %w", err)
+			}
+			return pods, errs
+		}
+	}
+	for alias, id := range aliases {
+		if _, failed := errs[id]; failed {
+			continue
+		}
+		if pod := results[alias]; pod != nil {
+			pods[id] = pod
+		} else {
+			errs[id] = fmt.Errorf("pod %w: %s", ErrNotFound, id)
+		}
+	}
+
+	return pods, errs
+}
+
+// readBatchWindow is how long the first read in a batch waits for others to join
+const readBatchWindow = 20 * time.Millisecond
+
+// maxReadBatchSize bounds the number of pods read in one request
+const maxReadBatchSize = 25
+
+// GetPodBatched retrieves a pod, batching the request with any other pod
+// reads issued concurrently, such as the reads of a refresh
+func (c *Client) GetPodBatched(ctx context.Context, id string) (*Pod, error) {
+	return c.reader.read(ctx, id)
+}
+
+// readBatcher coalesces GetPod calls made within a short window into a
+// single request, so refreshing many pods does not queue one request per pod
+// behind the concurrent request limit
+type readBatcher struct {
+	client  *Client
+	window  time.Duration
+	mu      sync.Mutex
+	pending []readRequest
+}
+
+type readRequest struct {
+	id     string
+	result chan readResult
+}
+
+type readResult struct {
+	pod *Pod
+	err error
+}
+
+func (b *readBatcher) read(ctx context.Context, id string) (*Pod, error) {
+	result := make(chan readResult, 1)
+
+	b.mu.Lock()
+	b.pending = append(b.pending, readRequest{id: id, result: result})
+	if len(b.pending) == 1 {
+		time.AfterFunc(b.window, b.flush)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-result:
+		return r.pod, r.err
+	}
+}
+
+func (b *readBatcher) flush() {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	for start := 0; start < len(batch); start += maxReadBatchSize {
+		end := min(start+maxReadBatchSize, len(batch))
+
+		// The same pod may be read more than once in a window
+		ids := make([]string, 0, end-start)
+		seen := make(map[string]bool, end-start)
+		for _, req := range batch[start:end] {
+			if !seen[req.id] {
+				seen[req.id] = true
+				ids = append(ids, req.id)
+			}
+		}
+
+		// The batch is shared, so one caller giving up does not cancel it
+		pods, errs := b.client.GetPods(context.Background(), ids)
+		for _, req := range batch[start:end] {
+			req.result <- readResult{pod: pods[req.id], err: errs[req.id]}
+		}
+	}
+}
+
+// TerminatePodBatched terminates a pod, batching the request with any other
+// terminations issued concurrently
+func (c *Client) TerminatePodBatched(ctx context.Context, id string) error {
+	return c.terminator.terminate(ctx, id)
+}
+
+func (b *terminateBatcher) terminate(ctx context.Context, id string) error {
+	result := make(chan error, 1)
+
+	b.mu.Lock()
+	b.pending = append(b.pending, terminateRequest{id: id, result: result})
+	if len(b.pending) == 1 {
+		time.AfterFunc(b.window, b.flush)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-result:
+		return err
+	}
+}
+
+func (b *terminateBatcher) flush() {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	for start := 0; start < len(batch); start += maxTerminateBatchSize {
+		end := min(start+maxTerminateBatchSize, len(batch))
+
+		ids := make([]string, 0, end-start)
+		for _, req := range batch[start:end] {
+			ids = append(ids, req.id)
+		}
+
+		// The batch is shared, so one caller giving up does not cancel it
+		errs := b.client.TerminatePods(context.Background(), ids)
+		for _, req := range batch[start:end] {
+			req.result <- errs[req.id]
+		}
+	}
+}
+
+// costBudget keeps a running total of the estimated hourly cost of the pods
+// created by one run, so a run cannot provision more than a cap
+type costBudget struct {
+	mu    sync.Mutex
+	limit float64
+	total float64
+}
+
+// ReserveCost adds a pod's estimated hourly cost to the running total before
+// it is deployed. It fails, leaving the total unchanged, if the total would
+// exceed max_total_cost_per_hr. Without a cap it always succeeds.
+func (c *Client) ReserveCost(costPerHr float64) error {
+	b := c.budget
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.total+costPerHr > b.limit {
+		return fmt.Errorf("deploying a pod costing $%.3f/hr would bring this run's total to $%.3f/hr, over the $%.3f/hr limit ($%.3f/hr already reserved)",
+			costPerHr, b.total+costPerHr, b.limit, b.total)
+	}
+	b.total += costPerHr
+	return nil
+}
+
+// ReleaseCost removes a reservation made by ReserveCost, for pods that failed
+// to deploy
+func (c *Client) ReleaseCost(costPerHr float64) {
+	b := c.budget
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.total -= costPerHr
+}
+
+const stopPodMutation = `mutation PodStop($input: PodStopInput!) {
+	podStop(input: $input) {
+		id
+		desiredStatus
+	}
+}`
+
+// StopPod stops a pod (without terminating it)
+func (c *Client) StopPod(ctx context.Context, id string) (*Pod, error) {
 	variables := map[string]interface{}{
 		"input": map[string]string{
 			"podId": id,
 		},
 	}
 
-	data, err := c.doRequest(query, variables)
+	data, err := c.gql.Do(ctx, stopPodMutation, variables)
+	err = c.partialSuccess(ctx, data, err, "podStop")
 	if err != nil {
 		return nil, fmt.Errorf("failed to stop pod: %w", err)
 	}
@@ -423,19 +1589,24 @@ func (c *Client) StopPod(id string) (*Pod, error) {
 	return result.PodStop, nil
 }
 
-// ResumePod resumes/starts a stopped pod
-func (c *Client) ResumePod(id string, gpuCount int) (*Pod, error) {
-	query := `mutation PodResume($input: PodResumeInput!) {
-		podResume(input: $input) {
-			id
-			desiredStatus
-			imageName
-			machineId
-			machine {
-				podHostId
-			}
+const resumePodMutation = `mutation PodResume($input: PodResumeInput!) {
+	podResume(input: $input) {
+		id
+		desiredStatus
+		imageName
+		machineId
+		machine {
+			podHostId
 		}
-	}`
+	}
+}`
+
+// ResumePod resumes/starts a stopped pod. gpuCount must be the pod's GPU
+// count; the API does not default it.
+func (c *Client) ResumePod(ctx context.Context, id string, gpuCount int) (*Pod, error) {
+	if gpuCount < 1 {
+		return nil, fmt.Errorf("cannot resume pod %s: gpu count must be at least 1, got %d", id, gpuCount)
+	}
 
 	variables := map[string]interface{}{
 		"input": map[string]interface{}{
@@ -444,7 +1615,8 @@ func (c *Client) ResumePod(id string, gpuCount int) (*Pod, error) {
 		},
 	}
 
-	data, err := c.doRequest(query, variables)
+	data, err := c.gql.Do(ctx, resumePodMutation, variables)
+	err = c.partialSuccess(ctx, data, err, "podResume")
 	if err != nil {
 		return nil, fmt.Errorf("failed to resume pod: %w", err)
 	}
@@ -459,28 +1631,260 @@ func (c *Client) ResumePod(id string, gpuCount int) (*Pod, error) {
 	return result.PodResume, nil
 }
 
+const resumeInterruptiblePodMutation = `mutation PodBidResume($input: PodBidResumeInput!) {
+	podBidResume(input: $input) {
+		id
+		desiredStatus
+		imageName
+		machineId
+		machine {
+			podHostId
+		}
+	}
+}`
+
+// ResumeInterruptiblePod resumes a stopped spot pod with a new bid of
+// bidPerGpu per GPU per hour. gpuCount must be the pod's GPU count.
+func (c *Client) ResumeInterruptiblePod(ctx context.Context, id string, gpuCount int, bidPerGpu float64) (*Pod, error) {
+	if gpuCount < 1 {
+		return nil, fmt.Errorf("cannot resume pod %s: gpu count must be at least 1, got %d", id, gpuCount)
+	}
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"podId":     id,
+			"gpuCount":  gpuCount,
+			"bidPerGpu": bidPerGpu,
+		},
+	}
+
+	data, err := c.gql.Do(ctx, resumeInterruptiblePodMutation, variables)
+	err = c.partialSuccess(ctx, data, err, "podBidResume")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume pod: %w", err)
+	}
+
+	var result struct {
+		PodBidResume *Pod `json:"podBidResume"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pod response: %w", err)
+	}
+
+	return result.PodBidResume, nil
+}
+
+// PodEditInput represents the input for editing a pod in place.
+// The API replaces the full pod configuration, so all fields should be set.
+type PodEditInput struct {
+	PodID             string
+	ImageName         string
+	DockerArgs        string
+	Ports             string
+	ContainerDiskInGb int
+	VolumeInGb        int
+	VolumeMountPath   string
+	Env               []EnvVar
+}
+
+// NewPodEditInput builds an edit input that keeps the pod's current configuration
+func NewPodEditInput(pod *Pod) *PodEditInput {
+	return &PodEditInput{
+		PodID:             pod.ID,
+		ImageName:         pod.ImageName,
+		DockerArgs:        pod.DockerArgs,
+		Ports:             pod.Ports,
+		ContainerDiskInGb: pod.ContainerDiskInGb,
+		VolumeInGb:        pod.VolumeInGb,
+		VolumeMountPath:   pod.VolumeMountPath,
+		Env:               append([]EnvVar(nil), pod.Env...),
+	}
+}
+
+const editPodMutation = `mutation PodEditJob($input: PodEditJobInput!) {
+	podEditJob(input: $input) {
+		id
+		imageName
+		env
+		ports
+		dockerArgs
+		containerDiskInGb
+		volumeInGb
+		volumeMountPath
+	}
+}`
+
+// EditPod updates a pod's configuration in place. This restarts the container.
+func (c *Client) EditPod(ctx context.Context, input *PodEditInput) (*Pod, error) {
+	envList := make([]map[string]string, len(input.Env))
+	for i, e := range input.Env {
+		envList[i] = map[string]string{"key": e.Key, "value": e.Value}
+	}
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"podId":             input.PodID,
+			"imageName":         input.ImageName,
+			"dockerArgs":        input.DockerArgs,
+			"ports":             input.Ports,
+			"containerDiskInGb": input.ContainerDiskInGb,
+			"volumeInGb":        input.VolumeInGb,
+			"volumeMountPath":   input.VolumeMountPath,
+			"env":               envList,
+		},
+	}
+
+	data, err := c.gql.Do(ctx, editPodMutation, variables)
+	err = c.partialSuccess(ctx, data, err, "podEditJob")
+	if err != nil {
+		return nil, fmt.Errorf("failed to edit pod: %w", err)
+	}
+
+	var result struct {
+		PodEditJob *Pod `json:"podEditJob"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pod response: %w", err)
+	}
+
+	return result.PodEditJob, nil
+}
+
 // GpuType represents a GPU type available on RunPod
 type GpuType struct {
-	ID             string  `json:"id"`
-	DisplayName    string  `json:"displayName"`
-	MemoryInGb     int     `json:"memoryInGb"`
-	SecureCloud    bool    `json:"secureCloud"`
-	CommunityCloud bool    `json:"communityCloud"`
+	ID                   string          `json:"id"`
+	DisplayName          string          `json:"displayName"`
+	Manufacturer         string          `json:"manufacturer"`
+	MemoryInGb           int             `json:"memoryInGb"`
+	SecureCloud          bool            `json:"secureCloud"`
+	CommunityCloud       bool            `json:"communityCloud"`
+	SecurePrice          float64         `json:"securePrice"`
+	CommunityPrice       float64         `json:"communityPrice"`
+	SecureLowestPrice    *GpuLowestPrice `json:"secureLowestPrice"`
+	CommunityLowestPrice *GpuLowestPrice `json:"communityLowestPrice"`
 }
 
-// ListGpuTypes retrieves all available GPU types
-func (c *Client) ListGpuTypes() ([]GpuType, error) {
-	query := `query GpuTypes {
-		gpuTypes {
-			id
-			displayName
-			memoryInGb
-			secureCloud
-			communityCloud
+// PricePerGpu returns the on-demand hourly price of one GPU of this type in
+// cloudType. For ALL, the higher of the two prices is returned since either
+// cloud may be chosen. It returns false when the API reports no price.
+func (g *GpuType) PricePerGpu(cloudType string) (float64, bool) {
+	var price float64
+	switch cloudType {
+	case "SECURE":
+		price = g.SecurePrice
+	case "COMMUNITY":
+		price = g.CommunityPrice
+	default:
+		price = math.Max(g.SecurePrice, g.CommunityPrice)
+	}
+	return price, price > 0
+}
+
+// GpuLowestPrice holds the current offer for a GPU type in one cloud
+type GpuLowestPrice struct {
+	MinimumBidPrice       *float64 `json:"minimumBidPrice"`
+	MaxUnreservedGpuCount *int     `json:"maxUnreservedGpuCount"`
+}
+
+// SpotPrice returns the lowest hourly spot (interruptible) bid for one GPU of
+// this type across both clouds. It returns false when neither cloud reports a
+// bid price.
+func (g *GpuType) SpotPrice() (float64, bool) {
+	var spot float64
+	for _, price := range []*GpuLowestPrice{g.SecureLowestPrice, g.CommunityLowestPrice} {
+		if price == nil || price.MinimumBidPrice == nil || *price.MinimumBidPrice <= 0 {
+			continue
+		}
+		if spot == 0 || *price.MinimumBidPrice < spot {
+			spot = *price.MinimumBidPrice
+		}
+	}
+	return spot, spot > 0
+}
+
+// AvailableCount returns how many GPUs of this type are unreserved in the
+// secure or community cloud. It returns 0 when the API reports no count.
+func (g *GpuType) AvailableCount(secure bool) int {
+	price := g.CommunityLowestPrice
+	if secure {
+		price = g.SecureLowestPrice
+	}
+	if price == nil || price.MaxUnreservedGpuCount == nil {
+		return 0
+	}
+	return *price.MaxUnreservedGpuCount
+}
+
+// gpuTypesCacheTTL is how long a fetched GPU type list is reused. It covers
+// a single plan, so the data source and the plan-time gpu_type_id check see
+// the same list without each fetching it.
+var gpuTypesCacheTTL = 30 * time.Second
+
+// gpuTypesCache holds the most recently fetched GPU type list
+type gpuTypesCache struct {
+	mu        sync.Mutex
+	gpuTypes  []GpuType
+	fetchedAt time.Time
+}
+
+// ListGpuTypes retrieves all available GPU types. The list is cached for
+// gpuTypesCacheTTL; concurrent callers wait for a single fetch.
+func (c *Client) ListGpuTypes(ctx context.Context) ([]GpuType, error) {
+	c.gpuTypes.mu.Lock()
+	defer c.gpuTypes.mu.Unlock()
+
+	if c.gpuTypes.gpuTypes != nil && time.Since(c.gpuTypes.fetchedAt) < gpuTypesCacheTTL {
+		return slices.Clone(c.gpuTypes.gpuTypes), nil
+	}
+
+	gpuTypes, err := c.fetchGpuTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.gpuTypes.gpuTypes = gpuTypes
+	c.gpuTypes.fetchedAt = time.Now()
+
+	return slices.Clone(gpuTypes), nil
+}
+
+// FindGpuType returns the GPU type with the given ID from the cached list
+func (c *Client) FindGpuType(ctx context.Context, id string) (*GpuType, error) {
+	gpuTypes, err := c.ListGpuTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range gpuTypes {
+		if gpuTypes[i].ID == id {
+			return &gpuTypes[i], nil
 		}
-	}`
+	}
+	return nil, fmt.Errorf("GPU type %w: %s", ErrNotFound, id)
+}
 
-	data, err := c.doRequest(query, nil)
+const listGpuTypesQuery = `query GpuTypes {
+	gpuTypes {
+		id
+		displayName
+		manufacturer
+		memoryInGb
+		secureCloud
+		communityCloud
+		securePrice
+		communityPrice
+		secureLowestPrice: lowestPrice(input: {gpuCount: 1, secureCloud: true}) {
+			minimumBidPrice
+			maxUnreservedGpuCount
+		}
+		communityLowestPrice: lowestPrice(input: {gpuCount: 1, secureCloud: false}) {
+			minimumBidPrice
+			maxUnreservedGpuCount
+		}
+	}
+}`
+
+// fetchGpuTypes queries the API for all available GPU types
+func (c *Client) fetchGpuTypes(ctx context.Context) ([]GpuType, error) {
+	data, err := c.gql.Do(ctx, listGpuTypesQuery, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -495,21 +1899,36 @@ func (c *Client) ListGpuTypes() ([]GpuType, error) {
 	return result.GpuTypes, nil
 }
 
-// GetGpuType retrieves a specific GPU type by ID
-func (c *Client) GetGpuType(id string) (*GpuType, error) {
-	query := `query GpuTypes {
-		gpuTypes(input: {id: "` + id + `"}) {
-			id
-			displayName
-			memoryInGb
-			secureCloud
-			communityCloud
+const getGpuTypeQuery = `query GpuType($input: GpuTypeFilter) {
+	gpuTypes(input: $input) {
+		id
+		displayName
+		manufacturer
+		memoryInGb
+		secureCloud
+		communityCloud
+		securePrice
+		communityPrice
+		secureLowestPrice: lowestPrice(input: {gpuCount: 1, secureCloud: true}) {
+			minimumBidPrice
+			maxUnreservedGpuCount
+		}
+		communityLowestPrice: lowestPrice(input: {gpuCount: 1, secureCloud: false}) {
+			minimumBidPrice
+			maxUnreservedGpuCount
 		}
-	}`
+	}
+}`
 
-	variables := map[string]interface{}{}
+// GetGpuType retrieves a specific GPU type by ID
+func (c *Client) GetGpuType(ctx context.Context, id string) (*GpuType, error) {
+	variables := map[string]interface{}{
+		"input": map[string]string{
+			"id": id,
+		},
+	}
 
-	data, err := c.doRequest(query, variables)
+	data, err := c.gql.Do(ctx, getGpuTypeQuery, variables)
 	if err != nil {
 		return nil, err
 	}
@@ -522,8 +1941,379 @@ func (c *Client) GetGpuType(id string) (*GpuType, error) {
 	}
 
 	if len(result.GpuTypes) == 0 {
-		return nil, fmt.Errorf("GPU type not found: %s", id)
+		return nil, fmt.Errorf("GPU type %w: %s", ErrNotFound, id)
 	}
 
 	return &result.GpuTypes[0], nil
 }
+
+// DataCenter represents a RunPod data center and its GPU stock
+type DataCenter struct {
+	ID              string            `json:"id"`
+	Name            string            `json:"name"`
+	Location        string            `json:"location"`
+	GpuAvailability []GpuAvailability `json:"gpuAvailability"`
+}
+
+// GpuAvailability describes the stock of a GPU type in a data center
+type GpuAvailability struct {
+	GpuTypeID   string `json:"gpuTypeId"`
+	StockStatus string `json:"stockStatus"`
+	Available   bool   `json:"available"`
+}
+
+const listDataCentersQuery = `query DataCenters {
+	dataCenters {
+		id
+		name
+		location
+		gpuAvailability {
+			gpuTypeId
+			stockStatus
+			available
+		}
+	}
+}`
+
+// ListDataCenters retrieves all data centers with their GPU availability
+func (c *Client) ListDataCenters(ctx context.Context) ([]DataCenter, error) {
+	data, err := c.gql.Do(ctx, listDataCentersQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		DataCenters []DataCenter `json:"dataCenters"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data centers response: %w", err)
+	}
+
+	return result.DataCenters, nil
+}
+
+// Template represents a RunPod pod template
+type Template struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	ImageName string  `json:"imageName"`
+	Env       EnvVars `json:"env"`
+}
+
+const getTemplateQuery = `query PodTemplates {
+	myself {
+		podTemplates {
+			id
+			name
+			imageName
+			env {
+				key
+				value
+			}
+		}
+	}
+}`
+
+// templatesCacheTTL is how long a fetched template list is reused. It covers
+// a single refresh, so reading many pods that use templates lists them once.
+var templatesCacheTTL = 30 * time.Second
+
+// templatesCache holds the most recently fetched template list
+type templatesCache struct {
+	mu        sync.Mutex
+	templates []Template
+	fetchedAt time.Time
+}
+
+// GetTemplate returns one of the account's pod templates by ID. The template
+// list is cached for templatesCacheTTL; concurrent callers wait for a single
+// fetch.
+func (c *Client) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	c.templates.mu.Lock()
+	defer c.templates.mu.Unlock()
+
+	if c.templates.templates == nil || time.Since(c.templates.fetchedAt) >= templatesCacheTTL {
+		templates, err := c.fetchTemplates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.templates.templates = templates
+		c.templates.fetchedAt = time.Now()
+	}
+
+	for _, template := range c.templates.templates {
+		if template.ID == id {
+			return &template, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, id)
+}
+
+// fetchTemplates queries the API for the account's pod templates
+func (c *Client) fetchTemplates(ctx context.Context) ([]Template, error) {
+	data, err := c.gql.Do(ctx, getTemplateQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Myself struct {
+			PodTemplates []Template `json:"podTemplates"`
+		} `json:"myself"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal templates response: %w", err)
+	}
+
+	templates := result.Myself.PodTemplates
+	if templates == nil {
+		templates = []Template{}
+	}
+	return templates, nil
+}
+
+// ValidatePodInput checks whether input is likely to deploy, returning a
+// message for each problem found. The API has no dry-run deploy, so this is
+// best effort: the input constraints are checked locally, and the GPU type,
+// its stock, the data center and the template against the current API data.
+// The error is only set when the API data cannot be read.
+func (c *Client) ValidatePodInput(ctx context.Context, input PodInput) ([]string, error) {
+	var messages []string
+
+	if input.ImageName == "" && input.TemplateID == "" {
+		messages = append(messages, "one of image_name or template_id must be set")
+	}
+	if input.GpuCount < 1 {
+		messages = append(messages, fmt.Sprintf("gpu_count must be at least 1, got %d", input.GpuCount))
+	}
+	if count := len(parsePorts(input.Ports)); count > maxExposedPorts {
+		messages = append(messages, fmt.Sprintf("ports exposes %d ports, but RunPod exposes at most %d per pod", count, maxExposedPorts))
+	}
+
+	gpuTypes, err := c.ListGpuTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var gpuType *GpuType
+	for i := range gpuTypes {
+		if gpuTypes[i].ID == input.GpuTypeID {
+			gpuType = &gpuTypes[i]
+		}
+	}
+	if gpuType == nil {
+		messages = append(messages, fmt.Sprintf("GPU type %q does not exist", input.GpuTypeID))
+	} else if input.DataCenterID == "" {
+		messages = append(messages, gpuStockMessages(gpuType, input.CloudType, input.GpuCount)...)
+	}
+
+	if input.DataCenterID != "" {
+		dataCenters, err := c.ListDataCenters(ctx)
+		if err != nil {
+			return nil, err
+		}
+		idx := slices.IndexFunc(dataCenters, func(dc DataCenter) bool { return dc.ID == input.DataCenterID })
+		if idx < 0 {
+			messages = append(messages, fmt.Sprintf("data center %q does not exist", input.DataCenterID))
+		} else if gpuType != nil {
+			available := slices.ContainsFunc(dataCenters[idx].GpuAvailability, func(ga GpuAvailability) bool {
+				return ga.GpuTypeID == gpuType.ID && ga.Available
+			})
+			if !available {
+				messages = append(messages, fmt.Sprintf("GPU type %q is not currently available in data center %q", gpuType.ID, input.DataCenterID))
+			}
+		}
+	}
+
+	if input.TemplateID != "" {
+		if _, err := c.GetTemplate(ctx, input.TemplateID); errors.Is(err, ErrTemplateNotFound) {
+			messages = append(messages, fmt.Sprintf("template %q does not exist", input.TemplateID))
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return messages, nil
+}
+
+// gpuStockMessages reports a GPU type that is not offered on cloudType or has
+// fewer unreserved GPUs than gpuCount. A count of zero also means the API did
+// not report one, so it is not treated as a shortage.
+func gpuStockMessages(gpuType *GpuType, cloudType string, gpuCount int) []string {
+	secure := cloudType != "COMMUNITY" && gpuType.SecureCloud
+	community := cloudType != "SECURE" && gpuType.CommunityCloud
+	if !secure && !community {
+		where := "any cloud"
+		if cloudType == "SECURE" || cloudType == "COMMUNITY" {
+			where = strings.ToLower(cloudType) + " cloud"
+		}
+		return []string{fmt.Sprintf("GPU type %q is not offered on %s", gpuType.ID, where)}
+	}
+
+	available := 0
+	if secure {
+		available = max(available, gpuType.AvailableCount(true))
+	}
+	if community {
+		available = max(available, gpuType.AvailableCount(false))
+	}
+	if available > 0 && available < gpuCount {
+		return []string{fmt.Sprintf("only %d unreserved %s GPUs are available, but gpu_count is %d", available, gpuType.ID, gpuCount)}
+	}
+	return nil
+}
+
+// NetworkVolume represents a RunPod network volume
+type NetworkVolume struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Size         int    `json:"size"`
+	DataCenterID string `json:"dataCenterId"`
+}
+
+const getNetworkVolumeQuery = `query NetworkVolume {
+	myself {
+		networkVolumes {
+			id
+			name
+			size
+			dataCenterId
+		}
+		pods {
+			id
+			networkVolumeId
+		}
+	}
+}`
+
+// GetNetworkVolume retrieves a network volume by ID, along with the IDs of the
+// pods it is attached to. Volumes and pods are fetched in a single request.
+func (c *Client) GetNetworkVolume(ctx context.Context, id string) (*NetworkVolume, []string, error) {
+	data, err := c.gql.Do(ctx, getNetworkVolumeQuery, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result struct {
+		Myself struct {
+			NetworkVolumes []NetworkVolume `json:"networkVolumes"`
+			Pods           []Pod           `json:"pods"`
+		} `json:"myself"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal network volume response: %w", err)
+	}
+
+	var volume *NetworkVolume
+	for i := range result.Myself.NetworkVolumes {
+		if result.Myself.NetworkVolumes[i].ID == id {
+			volume = &result.Myself.NetworkVolumes[i]
+			break
+		}
+	}
+	if volume == nil {
+		return nil, nil, fmt.Errorf("network volume %w: %s", ErrNotFound, id)
+	}
+
+	var podIDs []string
+	for _, pod := range result.Myself.Pods {
+		if pod.NetworkVolumeID == id {
+			podIDs = append(podIDs, pod.ID)
+		}
+	}
+
+	return volume, podIDs, nil
+}
+
+const listNetworkVolumesQuery = `query NetworkVolumes {
+	myself {
+		networkVolumes {
+			id
+			name
+			size
+			dataCenterId
+		}
+	}
+}`
+
+// FindNetworkVolume retrieves a network volume by ID. Unlike GetNetworkVolume
+// it does not list the account's pods.
+func (c *Client) FindNetworkVolume(ctx context.Context, id string) (*NetworkVolume, error) {
+	data, err := c.gql.Do(ctx, listNetworkVolumesQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Myself struct {
+			NetworkVolumes []NetworkVolume `json:"networkVolumes"`
+		} `json:"myself"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal network volumes response: %w", err)
+	}
+
+	for i := range result.Myself.NetworkVolumes {
+		if result.Myself.NetworkVolumes[i].ID == id {
+			return &result.Myself.NetworkVolumes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("network volume %w: %s", ErrNotFound, id)
+}
+
+const createNetworkVolumeMutation = `mutation CreateNetworkVolume($input: CreateNetworkVolumeInput!) {
+	createNetworkVolume(input: $input) {
+		id
+		name
+		size
+		dataCenterId
+	}
+}`
+
+// CreateNetworkVolume creates a network volume of sizeInGb in a data center
+func (c *Client) CreateNetworkVolume(ctx context.Context, name string, sizeInGb int, dataCenterID string) (*NetworkVolume, error) {
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"name":         name,
+			"size":         sizeInGb,
+			"dataCenterId": dataCenterID,
+		},
+	}
+
+	data, err := c.gql.Do(ctx, createNetworkVolumeMutation, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network volume: %w", err)
+	}
+
+	var result struct {
+		CreateNetworkVolume *NetworkVolume `json:"createNetworkVolume"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal network volume response: %w", err)
+	}
+
+	if result.CreateNetworkVolume == nil {
+		return nil, fmt.Errorf("no network volume returned from API")
+	}
+
+	return result.CreateNetworkVolume, nil
+}
+
+const deleteNetworkVolumeMutation = `mutation DeleteNetworkVolume($input: DeleteNetworkVolumeInput!) {
+	deleteNetworkVolume(input: $input)
+}`
+
+// DeleteNetworkVolume deletes a network volume
+func (c *Client) DeleteNetworkVolume(ctx context.Context, id string) error {
+	variables := map[string]interface{}{
+		"input": map[string]string{
+			"id": id,
+		},
+	}
+
+	_, err := c.gql.Do(ctx, deleteNetworkVolumeMutation, variables)
+	if err != nil {
+		return fmt.Errorf("failed to delete network volume: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,968 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestTerminatePods_perPodErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGraphQLRequest(t, r)
+		if len(req.Variables) != 3 {
+			t.Errorf("expected 3 pods in one request, got %d", len(req.Variables))
+		}
+		fmt.Fprint(w, `{"data":{"t0":null,"t1":null,"t2":null},"errors":[{"message":"Pod not found","path":["t1"]}]}`)
+	})
+
+	errs := client.TerminatePods(context.Background(), []string{"pod-a", "pod-b", "pod-c"})
+
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if err := errs["pod-b"]; err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error for pod-b, got %v", err)
+	}
+}
+
+func TestTerminatePod_reportedFailure(t *testing.T) {
+	for body, wantErr := range map[string]bool{
+		`{"data":{"podTerminate":false}}`: true,
+		`{"data":{"podTerminate":null}}`:  false,
+		`{"data":{"podTerminate":true}}`:  false,
+	} {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+
+		err := client.TerminatePod(context.Background(), "pod-1")
+		if gotErr := err != nil; gotErr != wantErr {
+			t.Errorf("%s: expected error %v, got %v", body, wantErr, err)
+		}
+	}
+}
+
+func TestTerminatePods_reportedFailure(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"t0":null,"t1":false}}`)
+	})
+
+	errs := client.TerminatePods(context.Background(), []string{"pod-a", "pod-b"})
+
+	if len(errs) != 1 || errs["pod-b"] == nil {
+		t.Errorf("expected an error for pod-b only, got %v", errs)
+	}
+}
+
+func TestTerminatePods_doer(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		if !strings.HasPrefix(query, "mutation PodTerminateBatch(") {
+			t.Errorf("unexpected query: %s", query)
+		}
+		return nil, errors.Join(
+			&APIError{Message: "Pod is locked", Path: []interface{}{"t1"}},
+			&APIError{Message: "Something went wrong", Path: []interface{}{"t2"}},
+		)
+	})
+
+	errs := client.TerminatePods(context.Background(), []string{"pod-a", "pod-b", "pod-c"})
+
+	if len(errs) != 2 {
+		t.Errorf("expected errors for pod-b and pod-c only, got %v", errs)
+	}
+	if err := errs["pod-b"]; err == nil || !strings.Contains(err.Error(), "Pod is locked") {
+		t.Errorf("expected the first GraphQL error for pod-b, got %v", err)
+	}
+	if err := errs["pod-c"]; err == nil || !strings.Contains(err.Error(), "Something went wrong") {
+		t.Errorf("expected the second GraphQL error for pod-c, got %v", err)
+	}
+}
+
+func TestTerminatePodBatched_coalesces(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"data":{}}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if err := client.TerminatePodBatched(context.Background(), id); err != nil {
+				t.Errorf("unexpected error for %s: %s", id, err)
+			}
+		}(fmt.Sprintf("pod-%d", i))
+	}
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+// newLatencyTestClient returns a client whose stub API takes latency per request
+func newLatencyTestClient(b *testing.B, latency time.Duration) *Client {
+	return newTestClient(b, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(latency)
+		fmt.Fprint(w, `{"data":{}}`)
+	})
+}
+
+func benchmarkTeardown(b *testing.B, terminate func(client *Client, id string) error) {
+	const pods = 20
+	client := newLatencyTestClient(b, 5*time.Millisecond)
+
+	for n := 0; n < b.N; n++ {
+		var wg sync.WaitGroup
+		for i := 0; i < pods; i++ {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				if err := terminate(client, id); err != nil {
+					b.Error(err)
+				}
+			}(fmt.Sprintf("pod-%d", i))
+		}
+		wg.Wait()
+	}
+}
+
+func BenchmarkTeardown_serialized(b *testing.B) {
+	benchmarkTeardown(b, func(client *Client, id string) error {
+		return client.TerminatePod(context.Background(), id)
+	})
+}
+
+func BenchmarkTeardown_batched(b *testing.B) {
+	benchmarkTeardown(b, func(client *Client, id string) error {
+		return client.TerminatePodBatched(context.Background(), id)
+	})
+}
+
+// podReadHandler answers single and batched pod reads, echoing each
+// requested pod ID
+func podReadHandler(t testing.TB, latency time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(latency)
+		req := decodeGraphQLRequest(t, r)
+
+		data := make(map[string]interface{}, len(req.Variables))
+		for alias, input := range req.Variables {
+			id := input.(map[string]interface{})["podId"]
+			if alias == "input" {
+				alias = "pod"
+			}
+			data[alias] = map[string]interface{}{"id": id}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}
+}
+
+func TestGetPods_perPodErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGraphQLRequest(t, r)
+		if len(req.Variables) != 3 {
+			t.Errorf("expected 3 pods in one request, got %d", len(req.Variables))
+		}
+		fmt.Fprint(w, `{"data":{"p0":{"id":"pod-a"},"p1":null,"p2":null},"errors":[{"message":"Something went wrong","path":["p2"]}]}`)
+	})
+
+	pods, errs := client.GetPods(context.Background(), []string{"pod-a", "pod-b", "pod-c"})
+
+	if len(pods) != 1 || pods["pod-a"] == nil {
+		t.Errorf("expected pod-a to be read, got %v", pods)
+	}
+	if err := errs["pod-b"]; err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error for pod-b, got %v", err)
+	}
+	if err := errs["pod-c"]; err == nil || !strings.Contains(err.Error(), "Something went wrong") {
+		t.Errorf("expected GraphQL error for pod-c, got %v", err)
+	}
+}
+
+func TestGetPods_doer(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		if !strings.HasPrefix(query, "query PodBatch(") {
+			t.Errorf("unexpected query: %s", query)
+		}
+		return json.RawMessage(`{"p0":{"id":"pod-a"},"p1":null,"p2":null}`), errors.Join(
+			&APIError{Message: "Pod is locked", Path: []interface{}{"p1"}},
+			&APIError{Message: "Something went wrong", Path: []interface{}{"p2"}},
+		)
+	})
+
+	pods, errs := client.GetPods(context.Background(), []string{"pod-a", "pod-b", "pod-c"})
+
+	if len(pods) != 1 || pods["pod-a"] == nil {
+		t.Errorf("expected pod-a to be read, got %v", pods)
+	}
+	if err := errs["pod-b"]; err == nil || !strings.Contains(err.Error(), "Pod is locked") {
+		t.Errorf("expected the first GraphQL error for pod-b, got %v", err)
+	}
+	if err := errs["pod-c"]; err == nil || !strings.Contains(err.Error(), "Something went wrong") {
+		t.Errorf("expected the second GraphQL error for pod-c, got %v", err)
+	}
+
+	client = newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return nil, errors.New("connection refused")
+	})
+	if _, errs := client.GetPods(context.Background(), []string{"pod-a", "pod-b"}); len(errs) != 2 {
+		t.Errorf("expected a request failure to fail every pod, got %v", errs)
+	}
+}
+
+func TestGetPodBatched_coalesces(t *testing.T) {
+	var requests atomic.Int32
+	handler := podReadHandler(t, 0)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler(w, r)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			pod, err := client.GetPodBatched(context.Background(), id)
+			if err != nil {
+				t.Errorf("unexpected error for %s: %s", id, err)
+				return
+			}
+			if pod.ID != id {
+				t.Errorf("expected %s, got %s", id, pod.ID)
+			}
+		}(fmt.Sprintf("pod-%d", i))
+	}
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func benchmarkRefresh(b *testing.B, read func(client *Client, id string) (*Pod, error)) {
+	const pods = 50
+	client := newTestClient(b, podReadHandler(b, 5*time.Millisecond))
+
+	for n := 0; n < b.N; n++ {
+		var wg sync.WaitGroup
+		for i := 0; i < pods; i++ {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				if _, err := read(client, id); err != nil {
+					b.Error(err)
+				}
+			}(fmt.Sprintf("pod-%d", i))
+		}
+		wg.Wait()
+	}
+}
+
+func BenchmarkRefresh_serialized(b *testing.B) {
+	benchmarkRefresh(b, func(client *Client, id string) (*Pod, error) {
+		return client.GetPod(context.Background(), id)
+	})
+}
+
+func BenchmarkRefresh_batched(b *testing.B) {
+	benchmarkRefresh(b, func(client *Client, id string) (*Pod, error) {
+		return client.GetPodBatched(context.Background(), id)
+	})
+}
+
+func TestNewClient_idleConnTimeout(t *testing.T) {
+	client := NewClient("test-key", WithIdleConnTimeout(5*time.Second))
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", client.httpClient.Transport)
+	}
+	if transport.IdleConnTimeout != 5*time.Second {
+		t.Errorf("expected idle timeout 5s, got %s", transport.IdleConnTimeout)
+	}
+	if transport.HTTP2 == nil || transport.HTTP2.SendPingTimeout <= 0 {
+		t.Error("expected HTTP/2 health check pings to be enabled")
+	}
+}
+
+func TestDoRequest_customHeaders(t *testing.T) {
+	var got http.Header
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		fmt.Fprint(w, `{"data":{"myself":{"id":"user-1"}}}`)
+	})
+	WithHeaders(map[string]string{
+		"X-Team-Id":    "team-42",
+		"Content-Type": "text/plain",
+	})(client)
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.Get("X-Team-Id") != "team-42" {
+		t.Errorf("expected custom header to be sent, got %q", got.Get("X-Team-Id"))
+	}
+	if got.Get("Content-Type") != "application/json" {
+		t.Errorf("expected Content-Type not to be overridden, got %q", got.Get("Content-Type"))
+	}
+}
+
+func TestDoRequest_bearerAuth(t *testing.T) {
+	var got *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Clone(context.Background())
+		fmt.Fprint(w, `{"data":{"myself":{"id":"user-1"}}}`)
+	})
+	WithHeaders(map[string]string{"Authorization": "Basic override"})(client)
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if auth := got.Header.Get("Authorization"); auth != "Bearer test-key" {
+		t.Errorf("expected bearer auth with the API key, got %q", auth)
+	}
+	if got.URL.RawQuery != "" {
+		t.Errorf("expected no query string, got %q", got.URL.RawQuery)
+	}
+}
+
+func TestExecute_debugLogging(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// A response echoing the key must not leak it into the logs
+		fmt.Fprint(w, `{"data":{"podResume":{"id":"pod-1"}},"errors":[{"message":"bad key test-key"}]}`)
+	})
+
+	if _, err := client.Do(ctx, resumePodMutation, map[string]interface{}{"input": map[string]interface{}{"podId": "pod-1"}}); err == nil {
+		t.Fatal("expected the GraphQL error")
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode logs: %s", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected a request and a response entry, got %v", entries)
+	}
+	request, response := entries[0], entries[1]
+	if request["operation"] != "PodResume" || request["variables"] != `{"input":{"podId":"pod-1"}}` {
+		t.Errorf("unexpected request entry: %v", request)
+	}
+	if response["operation"] != "PodResume" || response["status"] != float64(http.StatusOK) {
+		t.Errorf("unexpected response entry: %v", response)
+	}
+	if strings.Contains(output.String(), "test-key") {
+		t.Errorf("expected the API key to be masked, got %s", output.String())
+	}
+	if body, _ := response["body"].(string); !strings.Contains(body, "bad key ***") {
+		t.Errorf("expected the masked response body, got %q", body)
+	}
+}
+
+func TestGraphQLOperationName(t *testing.T) {
+	tests := map[string]string{
+		getPodQuery:                 "Pod",
+		terminatePodMutation:        "PodTerminate",
+		pingQuery:                   "Ping",
+		"query { myself { id } }":   "anonymous",
+		"\n\tmutation Custom { x }": "Custom",
+	}
+	for query, want := range tests {
+		if got := graphQLOperationName(query); got != want {
+			t.Errorf("graphQLOperationName(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestTruncateForLog(t *testing.T) {
+	body := []byte(`{"query":"` + strings.Repeat("x", 100) + `"}`)
+
+	got := truncateForLog(body, 20)
+	if !strings.HasPrefix(got, string(body[:20])) || !strings.HasSuffix(got, "... (truncated, 112 bytes total)") {
+		t.Errorf("expected body truncated to 20 bytes, got %q", got)
+	}
+	if got := truncateForLog(body, 0); got != string(body) {
+		t.Errorf("expected no truncation with a limit of 0, got %q", got)
+	}
+	if got := truncateForLog(body, len(body)); got != string(body) {
+		t.Errorf("expected no truncation at the limit, got %q", got)
+	}
+
+	// A multi-byte character is not split
+	if got := truncateForLog([]byte("aé"), 2); got != "a... (truncated, 3 bytes total)" {
+		t.Errorf("unexpected truncation of multi-byte body: %q", got)
+	}
+}
+
+func TestGraphQLWarningPatterns(t *testing.T) {
+	body := `{"data":{"pod":{"id":"pod-1","name":"train"}},"errors":[{"message":"Field 'machineId' is deprecated"}]}`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+
+	// Strict by default
+	if _, err := client.GetPod(context.Background(), "pod-1"); err == nil {
+		t.Fatal("expected the GraphQL error to fail the request")
+	}
+
+	WithGraphQLWarningPatterns([]*regexp.Regexp{regexp.MustCompile(`is deprecated`)})(client)
+	pod, err := client.GetPod(context.Background(), "pod-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pod.Name != "train" {
+		t.Errorf("expected the returned data to be usable, got %+v", pod)
+	}
+
+	var diags diag.Diagnostics
+	appendGraphQLWarnings(&diags, client)
+	if len(diags) != 1 || diags.HasError() || !strings.Contains(diags[0].Detail(), "deprecated") {
+		t.Errorf("expected a single warning, got %v", diags)
+	}
+	if warnings := client.TakeWarnings(); len(warnings) != 0 {
+		t.Errorf("expected warnings to be cleared once reported, got %v", warnings)
+	}
+
+	body = `{"data":{"pod":null},"errors":[{"message":"Something went wrong"}]}`
+	if _, err := client.GetPod(context.Background(), "pod-1"); err == nil || !strings.Contains(err.Error(), "Something went wrong") {
+		t.Errorf("expected a non-matching error to stay fatal, got %v", err)
+	}
+}
+
+func TestHeaderNameRegexp(t *testing.T) {
+	for _, name := range []string{"X-Team-Id", "x_gateway", "Api-Version"} {
+		if !headerNameRegexp.MatchString(name) {
+			t.Errorf("expected %q to be valid", name)
+		}
+	}
+	for _, name := range []string{"", "X Team", "X-Team:", "Ümlaut"} {
+		if headerNameRegexp.MatchString(name) {
+			t.Errorf("expected %q to be invalid", name)
+		}
+	}
+}
+
+func TestCreatePod_publicIPUnavailable(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"errors":[{"message":"Public IP is not available in the selected data center"}]}`)
+	})
+
+	_, err := client.CreatePod(context.Background(), &PodInput{Name: "test", SupportPublicIP: true})
+	if !errors.Is(err, ErrPublicIPUnavailable) {
+		t.Errorf("expected ErrPublicIPUnavailable, got %v", err)
+	}
+
+	_, err = client.CreatePod(context.Background(), &PodInput{Name: "test"})
+	if err == nil || errors.Is(err, ErrPublicIPUnavailable) {
+		t.Errorf("expected an untyped error without support_public_ip, got %v", err)
+	}
+}
+
+func TestCreatePod_tooManyPorts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"errors":[{"message":"Maximum number of exposed ports exceeded"}]}`)
+	})
+
+	_, err := client.CreatePod(context.Background(), &PodInput{Name: "test", Ports: "8888/http,22/tcp"})
+	if !errors.Is(err, ErrTooManyPorts) {
+		t.Errorf("expected ErrTooManyPorts, got %v", err)
+	}
+
+	if isTooManyPortsError(errors.New("GraphQL error: There are no longer any instances available")) {
+		t.Error("expected a capacity error not to be a port limit error")
+	}
+}
+
+func TestIsImagePullFailure(t *testing.T) {
+	tests := map[string]bool{
+		"failed to pull image: pull access denied for private/app": true,
+		"manifest unknown: tag v9 not found":                       true,
+		"Pulling fs layer":                                         false,
+		"Insufficient GPUs available":                              false,
+		"":                                                         false,
+	}
+	for msg, want := range tests {
+		if got := isImagePullFailure(msg); got != want {
+			t.Errorf("isImagePullFailure(%q) = %v, want %v", msg, got, want)
+		}
+	}
+}
+
+func TestGetPod_envVars(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"pod":{"id":"pod-1","env":["A=1","B=x=y","EMPTY"]}}`), nil
+	})
+
+	pod, err := client.GetPod(context.Background(), "pod-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := EnvVars{{Key: "A", Value: "1"}, {Key: "B", Value: "x=y"}, {Key: "EMPTY", Value: ""}}
+	if !reflect.DeepEqual(pod.Env, want) {
+		t.Errorf("expected %v, got %v", want, pod.Env)
+	}
+}
+
+func TestCreatePod_input(t *testing.T) {
+	var input map[string]interface{}
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		input = variables["input"].(map[string]interface{})
+		return json.RawMessage(`{"podFindAndDeployOnDemand":{"id":"pod-1"}}`), nil
+	})
+
+	_, err := client.CreatePod(context.Background(), &PodInput{
+		Name:           "test",
+		ImageName:      "runpod/base",
+		GpuTypeID:      "NVIDIA RTX A4000",
+		GpuCount:       1,
+		Env:            []EnvVar{{Key: "A", Value: "1"}},
+		RegistryAuthID: "auth-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if input["gpuTypeId"] != "NVIDIA RTX A4000" {
+		t.Errorf("expected gpuTypeId in input, got %v", input["gpuTypeId"])
+	}
+	if input["containerRegistryAuthId"] != "auth-1" {
+		t.Errorf("expected containerRegistryAuthId in input, got %v", input["containerRegistryAuthId"])
+	}
+	if _, ok := input["dataCenterId"]; ok {
+		t.Error("expected unset dataCenterId to be omitted")
+	}
+	env := input["env"].([]map[string]string)
+	if len(env) != 1 || env[0]["key"] != "A" || env[0]["value"] != "1" {
+		t.Errorf("unexpected env input: %v", env)
+	}
+}
+
+func TestCreateInterruptiblePod(t *testing.T) {
+	var input map[string]interface{}
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		if query != createInterruptiblePodMutation {
+			t.Fatalf("unexpected query: %s", query)
+		}
+		input = variables["input"].(map[string]interface{})
+		return json.RawMessage(`{"podRentInterruptable":{"id":"pod-1"}}`), nil
+	})
+
+	pod, err := client.CreateInterruptiblePod(context.Background(), &PodInput{
+		Name:      "test",
+		ImageName: "runpod/base",
+		GpuTypeID: "NVIDIA RTX A4000",
+		GpuCount:  2,
+		BidPerGpu: 0.2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pod.ID != "pod-1" {
+		t.Errorf("expected pod-1, got %q", pod.ID)
+	}
+	if input["bidPerGpu"] != 0.2 || input["gpuTypeId"] != "NVIDIA RTX A4000" || input["gpuCount"] != 2 {
+		t.Errorf("unexpected input: %v", input)
+	}
+}
+
+func TestEnvVarsUnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  EnvVars
+	}{
+		"null":          {`null`, EnvVars{}},
+		"empty array":   {`[]`, EnvVars{}},
+		"string form":   {`["A=1","TOKEN=YWJj=="]`, EnvVars{{Key: "A", Value: "1"}, {Key: "TOKEN", Value: "YWJj=="}}},
+		"nested equals": {`["A=B=C"]`, EnvVars{{Key: "A", Value: "B=C"}}},
+		"key only":      {`["FLAG"]`, EnvVars{{Key: "FLAG", Value: ""}}},
+		"object form":   {`[{"key":"A","value":"1"}]`, EnvVars{{Key: "A", Value: "1"}}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var pod struct {
+				Env EnvVars `json:"env"`
+			}
+			if err := json.Unmarshal([]byte(`{"env":`+tt.input+`}`), &pod); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(pod.Env, tt.want) {
+				t.Errorf("expected %#v, got %#v", tt.want, pod.Env)
+			}
+		})
+	}
+
+	var env EnvVars
+	if err := json.Unmarshal([]byte(`{"A":"1"}`), &env); err == nil {
+		t.Error("expected an error for an unsupported shape")
+	}
+}
+
+func TestGraphQLEndpoint(t *testing.T) {
+	endpoint, err := graphQLEndpoint("https://gateway.example.com/", "/runpod/graphql")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if endpoint != "https://gateway.example.com/runpod/graphql" {
+		t.Errorf("unexpected endpoint %q", endpoint)
+	}
+
+	invalid := map[string][2]string{
+		"relative host":   {"gateway.example.com", "/graphql"},
+		"host with path":  {"https://gateway.example.com/api", "/graphql"},
+		"relative path":   {"https://gateway.example.com", "graphql"},
+		"path with query": {"https://gateway.example.com", "/graphql?x=1"},
+	}
+	for name, tt := range invalid {
+		if _, err := graphQLEndpoint(tt[0], tt[1]); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestResumePod_gpuCount(t *testing.T) {
+	var resumedWith interface{}
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		resumedWith = variables["input"].(map[string]interface{})["gpuCount"]
+		return json.RawMessage(`{"podResume":{"id":"pod-1","desiredStatus":"RUNNING"}}`), nil
+	})
+
+	if _, err := client.ResumePod(context.Background(), "pod-1", 0); err == nil {
+		t.Error("expected an error resuming without a gpu count")
+	}
+	if resumedWith != nil {
+		t.Fatalf("expected no request without a gpu count, got gpuCount %v", resumedWith)
+	}
+
+	if _, err := client.ResumePod(context.Background(), "pod-1", 2); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resumedWith != 2 {
+		t.Errorf("expected gpuCount 2, got %v", resumedWith)
+	}
+}
+
+func TestIsRegistryAuthFailure(t *testing.T) {
+	tests := map[string]bool{
+		"failed to pull image: unauthorized: authentication required":               true,
+		"Error response from daemon: pull access denied, repository does not exist": true,
+		"no basic auth credentials (registry 123.dkr.ecr.us-east-1.amazonaws.com)":  true,
+		"manifest unknown: tag v9 not found":                                        false,
+		"Insufficient GPUs available":                                               false,
+	}
+	for msg, want := range tests {
+		if got := isRegistryAuthFailure(msg); got != want {
+			t.Errorf("isRegistryAuthFailure(%q) = %v, want %v", msg, got, want)
+		}
+	}
+}
+
+func TestRetryDelay_perStatus(t *testing.T) {
+	client := NewClient("test-key",
+		WithRetryBaseDelay(http.StatusTooManyRequests, 5*time.Second),
+		WithRetryBaseDelay(http.StatusServiceUnavailable, time.Second),
+		WithRetryBaseDelay(http.StatusBadGateway, time.Second),
+	)
+
+	tests := []struct {
+		status  int
+		attempt int
+		want    time.Duration
+	}{
+		{http.StatusTooManyRequests, 0, 5 * time.Second},
+		{http.StatusTooManyRequests, 2, 20 * time.Second},
+		{http.StatusServiceUnavailable, 0, time.Second},
+		{http.StatusServiceUnavailable, 3, 8 * time.Second},
+	}
+	for _, tt := range tests {
+		got, ok := client.retryDelay(tt.status, tt.attempt)
+		if !ok || got != tt.want {
+			t.Errorf("retryDelay(%d, %d) = %s, %v; want %s", tt.status, tt.attempt, got, ok, tt.want)
+		}
+	}
+
+	for _, attempt := range []int{8, 40, 100} {
+		if got, _ := client.retryDelay(http.StatusTooManyRequests, attempt); got != maxRetryDelay {
+			t.Errorf("retryDelay(429, %d) = %s; want the %s cap", attempt, got, maxRetryDelay)
+		}
+	}
+
+	if _, ok := client.retryDelay(http.StatusBadGateway, 0); ok {
+		t.Error("expected 502 not to be retried")
+	}
+	if got, _ := NewClient("test-key").retryDelay(http.StatusTooManyRequests, 0); got != defaultRetryBaseDelay {
+		t.Errorf("expected default base delay %s, got %s", defaultRetryBaseDelay, got)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	client := NewClient("test-key")
+
+	delay := 100 * time.Millisecond
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		got := client.jitter(delay)
+		if got < 0 || got > delay {
+			t.Fatalf("jitter(%s) = %s, want within [0, %s]", delay, got, delay)
+		}
+		seen[got] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("expected jittered delays to vary, got %v", seen)
+	}
+	if got := client.jitter(0); got != 0 {
+		t.Errorf("expected no jitter for a zero delay, got %s", got)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	responses := map[string]ErrorCode{
+		`{"errors":[{"message":"Pod not found"}]}`:                                         ErrorCodeNotFound,
+		`{"errors":[{"message":"No pod with that id","extensions":{"code":"NOT_FOUND"}}]}`: ErrorCodeNotFound,
+		`{"errors":[{"message":"Pod was modified by another request, conflict"}]}`:         ErrorCodeConflict,
+		`{"errors":[{"message":"There are no longer any instances available"}]}`:           ErrorCodeUnknown,
+		`{"errors":[{"message":"Access denied","extensions":{"code":"UNAUTHENTICATED"}}]}`: ErrorCodeUnauthenticated,
+	}
+	for body, wantCode := range responses {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+
+		_, err := client.Do(context.Background(), getPodQuery, nil)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%s: expected an APIError, got %v", body, err)
+		}
+		if apiErr.Code != wantCode {
+			t.Errorf("%s: expected code %s, got %s", body, wantCode, apiErr.Code)
+		}
+		if got := IsNotFound(err); got != (wantCode == ErrorCodeNotFound) {
+			t.Errorf("%s: IsNotFound = %v", body, got)
+		}
+	}
+
+	// A null pod without a GraphQL error is also not found
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"pod":null}`), nil
+	})
+	if _, err := client.GetPod(context.Background(), "pod-1"); !IsNotFound(err) {
+		t.Errorf("expected a null pod to be not found, got %v", err)
+	}
+}
+
+func TestDo_partialData(t *testing.T) {
+	body := `{"data":{"podResume":{"id":"pod-1","desiredStatus":"RUNNING"}},"errors":[{"message":"Resumed on a different machine"}]}`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+
+	data, err := client.Do(context.Background(), resumePodMutation, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "Resumed on a different machine" {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if !strings.Contains(string(data), `"podResume"`) {
+		t.Errorf("expected the partial data to be returned with the error, got %s", data)
+	}
+
+	// A mutation that returned its object succeeds, reporting the error as a warning
+	pod, err := client.ResumePod(context.Background(), "pod-1", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pod.DesiredStatus != "RUNNING" {
+		t.Errorf("expected the resumed pod, got %+v", pod)
+	}
+	if warnings := client.TakeWarnings(); len(warnings) != 1 || warnings[0] != "Resumed on a different machine" {
+		t.Errorf("expected the error as a warning, got %v", warnings)
+	}
+
+	body = `{"data":{"podResume":null},"errors":[{"message":"Not enough free GPUs on the host machine"}]}`
+	if _, err := client.ResumePod(context.Background(), "pod-1", 1); err == nil || !strings.Contains(err.Error(), "free GPUs") {
+		t.Errorf("expected a resume without a pod to fail, got %v", err)
+	}
+	if warnings := client.TakeWarnings(); len(warnings) != 0 {
+		t.Errorf("expected no warnings for a failed resume, got %v", warnings)
+	}
+}
+
+func TestDoRequest_cancelledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// The user cancels the apply while the request is rate limited
+		cancel()
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	for status := range client.retryDelays {
+		client.retryDelays[status] = time.Hour
+	}
+
+	start := time.Now()
+	_, err := client.GetPod(ctx, "pod-1")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Minute {
+		t.Errorf("expected the backoff to be interrupted, took %s", elapsed)
+	}
+}
+
+func TestExecute_maxConcurrentRequests(t *testing.T) {
+	const limit = 3
+
+	var mu sync.Mutex
+	var inFlight, peak int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprint(w, `{"data":{"pod":{"id":"pod-1"}}}`)
+	})
+	WithMaxConcurrentRequests(limit)(client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4*limit; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetPod(context.Background(), "pod-1"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > limit {
+		t.Errorf("expected at most %d requests in flight, got %d", limit, peak)
+	}
+	if peak < 2 {
+		t.Errorf("expected requests to run concurrently, got a peak of %d", peak)
+	}
+}
+
+func TestExecute_cancelledWaitingForSlot(t *testing.T) {
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fmt.Fprint(w, `{"data":{"pod":{"id":"pod-1"}}}`)
+	})
+	WithMaxConcurrentRequests(1)(client)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.GetPod(context.Background(), "pod-1")
+	}()
+	// Wait for the first request to take the only slot
+	for len(client.requests) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetPod(ctx, "pod-2"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait for a slot to end with the context, got %v", err)
+	}
+
+	close(release)
+	<-done
+}
+
+func TestGetPodBatched_cancelled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"p0":{"id":"pod-1"}}}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetPodBatched(ctx, "pod-1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation error, got %v", err)
+	}
+
+	// The batch still completes for callers that did not give up
+	pod, err := client.GetPodBatched(context.Background(), "pod-1")
+	if err != nil || pod.ID != "pod-1" {
+		t.Errorf("expected pod-1, got %v, %v", pod, err)
+	}
+}
+
+func TestMaxRetries(t *testing.T) {
+	for _, retries := range []int{0, 2, defaultMaxRetries} {
+		requests := 0
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		WithMaxRetries(retries)(client)
+
+		err := client.Ping(context.Background())
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("retries %d: expected the 503 to be returned, got %v", retries, err)
+		}
+		if requests != retries+1 {
+			t.Errorf("retries %d: expected %d requests, got %d", retries, retries+1, requests)
+		}
+	}
+}
+
+func TestGetPod_registryAuthID(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		if !strings.Contains(query, "containerRegistryAuthId") {
+			t.Error("expected the pod query to request containerRegistryAuthId")
+		}
+		return json.RawMessage(`{"pod":{"id":"pod-1","containerRegistryAuthId":"auth-1"}}`), nil
+	})
+
+	pod, err := client.GetPod(context.Background(), "pod-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pod.RegistryAuthID != "auth-1" {
+		t.Errorf("expected auth-1, got %q", pod.RegistryAuthID)
+	}
+}
+
+func TestCreatePod_cudaVersionUnsupported(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return nil, fmt.Errorf("GraphQL error: No machine with a driver supporting CUDA 12.8 is available")
+	})
+
+	_, err := client.CreatePod(context.Background(), &PodInput{Name: "test", AllowedCudaVersions: []string{"12.8"}})
+	if !errors.Is(err, ErrCUDAVersionUnsupported) {
+		t.Errorf("expected ErrCUDAVersionUnsupported, got %v", err)
+	}
+
+	_, err = client.CreatePod(context.Background(), &PodInput{Name: "test"})
+	if errors.Is(err, ErrCUDAVersionUnsupported) {
+		t.Errorf("expected an untyped error without allowed CUDA versions, got %v", err)
+	}
+}
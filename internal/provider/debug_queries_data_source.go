@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure interface compliance
+var _ datasource.DataSource = &DebugQueriesDataSource{}
+
+func NewDebugQueriesDataSource() datasource.DataSource {
+	return &DebugQueriesDataSource{}
+}
+
+// DebugQueriesDataSource defines the data source implementation
+type DebugQueriesDataSource struct{}
+
+// DebugQueriesDataSourceModel describes the data source data model
+type DebugQueriesDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Queries types.Map    `tfsdk:"queries"`
+}
+
+// debugQueries maps each client operation to the GraphQL document it sends.
+// The values are the client's own constants, so they cannot drift from what
+// the provider actually runs.
+var debugQueries = map[string]string{
+	"ping":                  pingQuery,
+	"get_myself":            getMyselfQuery,
+	"create_pod":            createPodMutation,
+	"create_spot_pod":       createInterruptiblePodMutation,
+	"get_pod":               getPodQuery,
+	"list_pods":             listPodsQuery,
+	"terminate_pod":         terminatePodMutation,
+	"stop_pod":              stopPodMutation,
+	"resume_pod":            resumePodMutation,
+	"resume_spot_pod":       resumeInterruptiblePodMutation,
+	"edit_pod":              editPodMutation,
+	"list_gpu_types":        listGpuTypesQuery,
+	"get_gpu_type":          getGpuTypeQuery,
+	"list_data_centers":     listDataCentersQuery,
+	"get_template":          getTemplateQuery,
+	"get_network_volume":    getNetworkVolumeQuery,
+	"list_network_volumes":  listNetworkVolumesQuery,
+	"create_network_volume": createNetworkVolumeMutation,
+	"delete_network_volume": deleteNetworkVolumeMutation,
+}
+
+func (d *DebugQueriesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_debug_queries"
+}
+
+func (d *DebugQueriesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Returns the GraphQL queries and mutations the provider sends for each operation, for reproducing " +
+			"issues in the RunPod GraphQL playground. Makes no API calls.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for this data source.",
+				Computed:    true,
+			},
+			"queries": schema.MapAttribute{
+				Description: "GraphQL documents keyed by operation (e.g. 'get_pod', 'create_pod'). Variables are not included.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *DebugQueriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DebugQueriesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	queries, diags := types.MapValueFrom(ctx, types.StringType, debugQueries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Queries = queries
+	data.ID = types.StringValue("debug_queries")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDebugQueriesDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "runpod_debug_queries" "all" {
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.runpod_debug_queries.all", "id", "debug_queries"),
+					resource.TestCheckResourceAttr("data.runpod_debug_queries.all", "queries.get_pod", getPodQuery),
+				),
+			},
+		},
+	})
+}
+
+func TestDebugQueries_matchClient(t *testing.T) {
+	var sent []string
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		sent = append(sent, query)
+		return json.RawMessage(`{"pod":{"id":"pod-1"},"podTerminate":null}`), nil
+	})
+
+	if _, err := client.GetPod(context.Background(), "pod-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := client.TerminatePod(context.Background(), "pod-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(sent) != 2 || sent[0] != debugQueries["get_pod"] || sent[1] != debugQueries["terminate_pod"] {
+		t.Errorf("expected the client to send the documented queries, got %q", sent)
+	}
+}
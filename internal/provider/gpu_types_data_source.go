@@ -24,21 +24,38 @@ type GpuTypesDataSource struct {
 
 // GpuTypesDataSourceModel describes the data source data model
 type GpuTypesDataSourceModel struct {
-	ID       types.String       `tfsdk:"id"`
-	GpuTypes []GpuTypeModel     `tfsdk:"gpu_types"`
+	ID       types.String        `tfsdk:"id"`
+	GpuTypes []GpuTypeModel      `tfsdk:"gpu_types"`
 	Filter   *GpuTypeFilterModel `tfsdk:"filter"`
 }
 
 type GpuTypeModel struct {
-	ID             types.String `tfsdk:"id"`
-	DisplayName    types.String `tfsdk:"display_name"`
-	MemoryInGb     types.Int64  `tfsdk:"memory_in_gb"`
-	SecureCloud    types.Bool   `tfsdk:"secure_cloud"`
-	CommunityCloud types.Bool   `tfsdk:"community_cloud"`
+	ID                       types.String                  `tfsdk:"id"`
+	DisplayName              types.String                  `tfsdk:"display_name"`
+	Manufacturer             types.String                  `tfsdk:"manufacturer"`
+	MemoryInGb               types.Int64                   `tfsdk:"memory_in_gb"`
+	SecureCloud              types.Bool                    `tfsdk:"secure_cloud"`
+	CommunityCloud           types.Bool                    `tfsdk:"community_cloud"`
+	SecureAvailableCount     types.Int64                   `tfsdk:"secure_available_count"`
+	CommunityAvailableCount  types.Int64                   `tfsdk:"community_available_count"`
+	SecurePrice              types.Float64                 `tfsdk:"secure_price"`
+	CommunityPrice           types.Float64                 `tfsdk:"community_price"`
+	SpotPrice                types.Float64                 `tfsdk:"spot_price"`
+	AvailabilityByDatacenter []DataCenterAvailabilityModel `tfsdk:"availability_by_datacenter"`
+}
+
+type DataCenterAvailabilityModel struct {
+	DataCenterID types.String `tfsdk:"data_center_id"`
+	Location     types.String `tfsdk:"location"`
+	StockStatus  types.String `tfsdk:"stock_status"`
+	Available    types.Bool   `tfsdk:"available"`
 }
 
 type GpuTypeFilterModel struct {
-	ID types.String `tfsdk:"id"`
+	ID             types.String `tfsdk:"id"`
+	MinMemoryInGb  types.Int64  `tfsdk:"min_memory_in_gb"`
+	SecureCloud    types.Bool   `tfsdk:"secure_cloud"`
+	CommunityCloud types.Bool   `tfsdk:"community_cloud"`
 }
 
 func (d *GpuTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -66,6 +83,10 @@ func (d *GpuTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 							Description: "The display name of the GPU type.",
 							Computed:    true,
 						},
+						"manufacturer": schema.StringAttribute{
+							Description: "The manufacturer of the GPU (e.g., 'Nvidia'). Null when the API does not report it.",
+							Computed:    true,
+						},
 						"memory_in_gb": schema.Int64Attribute{
 							Description: "The amount of memory in GB.",
 							Computed:    true,
@@ -78,18 +99,74 @@ func (d *GpuTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 							Description: "Whether this GPU type is available on community cloud.",
 							Computed:    true,
 						},
+						"secure_available_count": schema.Int64Attribute{
+							Description: "The number of unreserved GPUs of this type on secure cloud. Zero also when the count is unknown.",
+							Computed:    true,
+						},
+						"community_available_count": schema.Int64Attribute{
+							Description: "The number of unreserved GPUs of this type on community cloud. Zero also when the count is unknown.",
+							Computed:    true,
+						},
+						"secure_price": schema.Float64Attribute{
+							Description: "The on-demand hourly price in USD of one GPU of this type on secure cloud. Null when not offered.",
+							Computed:    true,
+						},
+						"community_price": schema.Float64Attribute{
+							Description: "The on-demand hourly price in USD of one GPU of this type on community cloud. Null when not offered.",
+							Computed:    true,
+						},
+						"spot_price": schema.Float64Attribute{
+							Description: "The lowest hourly spot (interruptible) bid in USD for one GPU of this type across both clouds. Null when not offered.",
+							Computed:    true,
+						},
+						"availability_by_datacenter": schema.ListNestedAttribute{
+							Description: "Stock of this GPU type in each data center. Empty when availability is unknown.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"data_center_id": schema.StringAttribute{
+										Description: "The ID of the data center (e.g., 'EU-RO-1').",
+										Computed:    true,
+									},
+									"location": schema.StringAttribute{
+										Description: "The location of the data center.",
+										Computed:    true,
+									},
+									"stock_status": schema.StringAttribute{
+										Description: "The stock status of the GPU type in the data center (e.g., 'High', 'Low').",
+										Computed:    true,
+									},
+									"available": schema.BoolAttribute{
+										Description: "Whether the GPU type can currently be deployed in the data center.",
+										Computed:    true,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
 		},
 		Blocks: map[string]schema.Block{
 			"filter": schema.SingleNestedBlock{
-				Description: "Filter GPU types by ID.",
+				Description: "Filter GPU types. GPU types must match every filter that is set.",
 				Attributes: map[string]schema.Attribute{
 					"id": schema.StringAttribute{
 						Description: "Filter by GPU type ID (e.g., 'NVIDIA GeForce RTX 3090').",
 						Optional:    true,
 					},
+					"min_memory_in_gb": schema.Int64Attribute{
+						Description: "Only GPU types with at least this much memory in GB.",
+						Optional:    true,
+					},
+					"secure_cloud": schema.BoolAttribute{
+						Description: "Only GPU types whose secure cloud availability matches.",
+						Optional:    true,
+					},
+					"community_cloud": schema.BoolAttribute{
+						Description: "Only GPU types whose community cloud availability matches.",
+						Optional:    true,
+					},
 				},
 			},
 		},
@@ -114,6 +191,8 @@ func (d *GpuTypesDataSource) Configure(ctx context.Context, req datasource.Confi
 }
 
 func (d *GpuTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, d.client)
+
 	var data GpuTypesDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -129,7 +208,7 @@ func (d *GpuTypesDataSource) Read(ctx context.Context, req datasource.ReadReques
 	// Check if we should filter by ID
 	if data.Filter != nil && !data.Filter.ID.IsNull() {
 		filterID := data.Filter.ID.ValueString()
-		gpuType, err := d.client.GetGpuType(filterID)
+		gpuType, err := d.client.FindGpuType(ctx, filterID)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error",
 				fmt.Sprintf("Unable to read GPU type: %s", err))
@@ -137,7 +216,7 @@ func (d *GpuTypesDataSource) Read(ctx context.Context, req datasource.ReadReques
 		}
 		gpuTypes = []GpuType{*gpuType}
 	} else {
-		gpuTypes, err = d.client.ListGpuTypes()
+		gpuTypes, err = d.client.ListGpuTypes(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error",
 				fmt.Sprintf("Unable to list GPU types: %s", err))
@@ -145,15 +224,38 @@ func (d *GpuTypesDataSource) Read(ctx context.Context, req datasource.ReadReques
 		}
 	}
 
+	if data.Filter != nil {
+		gpuTypes = filterGpuTypes(gpuTypes, data.Filter)
+	}
+
+	// Availability is best-effort; a failure leaves every list empty
+	dataCenters, err := d.client.ListDataCenters(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to read GPU availability by data center", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	availability := availabilityByGpuType(dataCenters)
+
 	// Convert to model
 	data.GpuTypes = make([]GpuTypeModel, len(gpuTypes))
 	for i, gt := range gpuTypes {
 		data.GpuTypes[i] = GpuTypeModel{
-			ID:             types.StringValue(gt.ID),
-			DisplayName:    types.StringValue(gt.DisplayName),
-			MemoryInGb:     types.Int64Value(int64(gt.MemoryInGb)),
-			SecureCloud:    types.BoolValue(gt.SecureCloud),
-			CommunityCloud: types.BoolValue(gt.CommunityCloud),
+			ID:                       types.StringValue(gt.ID),
+			DisplayName:              types.StringValue(gt.DisplayName),
+			Manufacturer:             optionalString(gt.Manufacturer),
+			MemoryInGb:               types.Int64Value(int64(gt.MemoryInGb)),
+			SecureCloud:              types.BoolValue(gt.SecureCloud),
+			CommunityCloud:           types.BoolValue(gt.CommunityCloud),
+			SecureAvailableCount:     types.Int64Value(int64(gt.AvailableCount(true))),
+			CommunityAvailableCount:  types.Int64Value(int64(gt.AvailableCount(false))),
+			SecurePrice:              optionalPrice(gt.PricePerGpu("SECURE")),
+			CommunityPrice:           optionalPrice(gt.PricePerGpu("COMMUNITY")),
+			SpotPrice:                optionalPrice(gt.SpotPrice()),
+			AvailabilityByDatacenter: availability[gt.ID],
+		}
+		if data.GpuTypes[i].AvailabilityByDatacenter == nil {
+			data.GpuTypes[i].AvailabilityByDatacenter = []DataCenterAvailabilityModel{}
 		}
 	}
 
@@ -166,3 +268,47 @@ func (d *GpuTypesDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// optionalPrice converts a GPU price to a Terraform value, using null when the
+// API reports no price
+func optionalPrice(price float64, ok bool) types.Float64 {
+	if !ok {
+		return types.Float64Null()
+	}
+	return types.Float64Value(price)
+}
+
+// availabilityByGpuType groups data center stock by GPU type ID
+func availabilityByGpuType(dataCenters []DataCenter) map[string][]DataCenterAvailabilityModel {
+	availability := make(map[string][]DataCenterAvailabilityModel)
+	for _, dc := range dataCenters {
+		for _, ga := range dc.GpuAvailability {
+			availability[ga.GpuTypeID] = append(availability[ga.GpuTypeID], DataCenterAvailabilityModel{
+				DataCenterID: types.StringValue(dc.ID),
+				Location:     types.StringValue(dc.Location),
+				StockStatus:  types.StringValue(ga.StockStatus),
+				Available:    types.BoolValue(ga.Available),
+			})
+		}
+	}
+	return availability
+}
+
+// filterGpuTypes keeps the GPU types matching every attribute set in filter
+// other than id, which is looked up before filtering
+func filterGpuTypes(gpuTypes []GpuType, filter *GpuTypeFilterModel) []GpuType {
+	var filtered []GpuType
+	for _, gt := range gpuTypes {
+		if !filter.MinMemoryInGb.IsNull() && int64(gt.MemoryInGb) < filter.MinMemoryInGb.ValueInt64() {
+			continue
+		}
+		if !filter.SecureCloud.IsNull() && gt.SecureCloud != filter.SecureCloud.ValueBool() {
+			continue
+		}
+		if !filter.CommunityCloud.IsNull() && gt.CommunityCloud != filter.CommunityCloud.ValueBool() {
+			continue
+		}
+		filtered = append(filtered, gt)
+	}
+	return filtered
+}
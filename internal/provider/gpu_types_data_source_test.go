@@ -1,8 +1,15 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -16,6 +23,7 @@ func TestAccGpuTypesDataSource_basic(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.runpod_gpu_types.all", "id", "gpu_types"),
 					resource.TestCheckResourceAttrSet("data.runpod_gpu_types.all", "gpu_types.#"),
+					resource.TestCheckResourceAttrSet("data.runpod_gpu_types.all", "gpu_types.0.availability_by_datacenter.#"),
 				),
 			},
 		},
@@ -54,3 +62,211 @@ data "runpod_gpu_types" "filtered" {
 }
 `
 }
+
+func TestAvailabilityByGpuType(t *testing.T) {
+	dataCenters := []DataCenter{
+		{
+			ID:       "EU-RO-1",
+			Location: "Romania",
+			GpuAvailability: []GpuAvailability{
+				{GpuTypeID: "NVIDIA RTX A6000", StockStatus: "High", Available: true},
+			},
+		},
+		{
+			ID:       "US-TX-3",
+			Location: "United States",
+			GpuAvailability: []GpuAvailability{
+				{GpuTypeID: "NVIDIA RTX A6000", StockStatus: "Low", Available: true},
+				{GpuTypeID: "NVIDIA A100 80GB PCIe", StockStatus: "", Available: false},
+			},
+		},
+	}
+
+	availability := availabilityByGpuType(dataCenters)
+
+	a6000 := availability["NVIDIA RTX A6000"]
+	if len(a6000) != 2 {
+		t.Fatalf("expected 2 data centers for A6000, got %d", len(a6000))
+	}
+	if a6000[0].DataCenterID.ValueString() != "EU-RO-1" || a6000[0].StockStatus.ValueString() != "High" {
+		t.Errorf("unexpected availability: %+v", a6000[0])
+	}
+	if len(availability["NVIDIA A100 80GB PCIe"]) != 1 {
+		t.Errorf("expected 1 data center for A100")
+	}
+	if len(availabilityByGpuType(nil)) != 0 {
+		t.Errorf("expected no availability for nil data centers")
+	}
+}
+
+func TestGpuTypeAvailableCount(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"gpuTypes":[
+			{"id":"NVIDIA RTX A4000","secureLowestPrice":{"maxUnreservedGpuCount":12},"communityLowestPrice":{"maxUnreservedGpuCount":null}},
+			{"id":"NVIDIA H100 80GB HBM3","secureLowestPrice":null,"communityLowestPrice":{"maxUnreservedGpuCount":3}}
+		]}}`)
+	})
+
+	gpuTypes, err := client.ListGpuTypes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(gpuTypes) != 2 {
+		t.Fatalf("expected 2 GPU types, got %d", len(gpuTypes))
+	}
+
+	if got := gpuTypes[0].AvailableCount(true); got != 12 {
+		t.Errorf("expected 12 secure GPUs, got %d", got)
+	}
+	if got := gpuTypes[0].AvailableCount(false); got != 0 {
+		t.Errorf("expected a null community count to be 0, got %d", got)
+	}
+	if got := gpuTypes[1].AvailableCount(true); got != 0 {
+		t.Errorf("expected a missing secure offer to be 0, got %d", got)
+	}
+	if got := gpuTypes[1].AvailableCount(false); got != 3 {
+		t.Errorf("expected 3 community GPUs, got %d", got)
+	}
+}
+
+func TestGpuTypePrices(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"gpuTypes":[
+			{"id":"NVIDIA RTX A4000","securePrice":0.4,"communityPrice":0.3,
+				"secureLowestPrice":{"minimumBidPrice":0.25},"communityLowestPrice":{"minimumBidPrice":0.2}},
+			{"id":"NVIDIA H100 80GB HBM3","securePrice":2.99,"communityPrice":0,
+				"secureLowestPrice":{"minimumBidPrice":null},"communityLowestPrice":null}
+		]}}`)
+	})
+
+	gpuTypes, err := client.ListGpuTypes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := optionalPrice(gpuTypes[0].SpotPrice()); got.ValueFloat64() != 0.2 {
+		t.Errorf("expected the lower community bid 0.2, got %s", got)
+	}
+	if got := optionalPrice(gpuTypes[0].PricePerGpu("SECURE")); got.ValueFloat64() != 0.4 {
+		t.Errorf("expected secure price 0.4, got %s", got)
+	}
+	if got := optionalPrice(gpuTypes[1].PricePerGpu("COMMUNITY")); !got.IsNull() {
+		t.Errorf("expected a zero community price to be null, got %s", got)
+	}
+	if got := optionalPrice(gpuTypes[1].SpotPrice()); !got.IsNull() {
+		t.Errorf("expected no spot price, got %s", got)
+	}
+}
+
+func TestListGpuTypes_sharedCache(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"data":{"gpuTypes":[{"id":"NVIDIA RTX A4000"},{"id":"NVIDIA RTX A5000"}]}}`)
+	})
+	r := &PodResource{client: client}
+
+	// The plan-time gpu_type_id check and the data source filter both read
+	// the cached list
+	if diags := r.gpuTypeIDDiagnostics(context.Background(), path.Root("gpu_type_id"), "NVIDIA RTX A4000"); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for a known GPU type, got %v", diags)
+	}
+	if diags := r.gpuTypeIDDiagnostics(context.Background(), path.Root("gpu_type_id"), "NVIDIA RTX A4001"); len(diags) != 1 || diags.HasError() {
+		t.Errorf("expected a warning for an unknown GPU type, got %v", diags)
+	}
+	gpuType, err := client.FindGpuType(context.Background(), "NVIDIA RTX A5000")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gpuType.ID != "NVIDIA RTX A5000" {
+		t.Errorf("unexpected GPU type: %s", gpuType.ID)
+	}
+
+	if requests != 1 {
+		t.Errorf("expected 1 request within the TTL, got %d", requests)
+	}
+
+	client.gpuTypes.fetchedAt = time.Now().Add(-gpuTypesCacheTTL)
+	if _, err := client.ListGpuTypes(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected the list to be fetched again after the TTL, got %d requests", requests)
+	}
+}
+
+func TestGpuTypeManufacturer(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"gpuTypes":[
+			{"id":"NVIDIA H100 80GB HBM3","manufacturer":"Nvidia"},
+			{"id":"NVIDIA RTX A4000","manufacturer":"Nvidia"},
+			{"id":"NVIDIA H200"}
+		]}}`)
+	})
+
+	gpuTypes, err := client.ListGpuTypes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gpuTypes[0].Manufacturer != "Nvidia" {
+		t.Errorf("expected manufacturer Nvidia, got %q", gpuTypes[0].Manufacturer)
+	}
+	if gpuTypes[2].Manufacturer != "" {
+		t.Errorf("expected a missing manufacturer to be empty, got %q", gpuTypes[2].Manufacturer)
+	}
+}
+
+func TestFilterGpuTypes(t *testing.T) {
+	gpuTypes := []GpuType{
+		{ID: "NVIDIA RTX A4000", MemoryInGb: 16, SecureCloud: true, CommunityCloud: true},
+		{ID: "NVIDIA A100 80GB PCIe", MemoryInGb: 80, SecureCloud: true, CommunityCloud: false},
+		{ID: "NVIDIA H100 80GB HBM3", MemoryInGb: 80, SecureCloud: false, CommunityCloud: true},
+	}
+
+	tests := []struct {
+		name   string
+		filter GpuTypeFilterModel
+		want   []string
+	}{
+		{
+			name:   "no filters",
+			filter: GpuTypeFilterModel{},
+			want:   []string{"NVIDIA RTX A4000", "NVIDIA A100 80GB PCIe", "NVIDIA H100 80GB HBM3"},
+		},
+		{
+			name:   "min memory is inclusive",
+			filter: GpuTypeFilterModel{MinMemoryInGb: types.Int64Value(80)},
+			want:   []string{"NVIDIA A100 80GB PCIe", "NVIDIA H100 80GB HBM3"},
+		},
+		{
+			name:   "secure cloud",
+			filter: GpuTypeFilterModel{SecureCloud: types.BoolValue(true)},
+			want:   []string{"NVIDIA RTX A4000", "NVIDIA A100 80GB PCIe"},
+		},
+		{
+			name:   "not in community cloud",
+			filter: GpuTypeFilterModel{CommunityCloud: types.BoolValue(false)},
+			want:   []string{"NVIDIA A100 80GB PCIe"},
+		},
+		{
+			name: "combined",
+			filter: GpuTypeFilterModel{
+				MinMemoryInGb:  types.Int64Value(40),
+				CommunityCloud: types.BoolValue(true),
+			},
+			want: []string{"NVIDIA H100 80GB HBM3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, gt := range filterGpuTypes(gpuTypes, &tt.filter) {
+				got = append(got, gt.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
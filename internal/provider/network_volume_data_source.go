@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure interface compliance
+var _ datasource.DataSource = &NetworkVolumeDataSource{}
+
+func NewNetworkVolumeDataSource() datasource.DataSource {
+	return &NetworkVolumeDataSource{}
+}
+
+// NetworkVolumeDataSource defines the data source implementation
+type NetworkVolumeDataSource struct {
+	client *Client
+}
+
+// NetworkVolumeDataSourceModel describes the data source data model
+type NetworkVolumeDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	SizeInGb       types.Int64  `tfsdk:"size_in_gb"`
+	DataCenterID   types.String `tfsdk:"data_center_id"`
+	InUse          types.Bool   `tfsdk:"in_use"`
+	AttachedPodIDs types.List   `tfsdk:"attached_pod_ids"`
+}
+
+func (d *NetworkVolumeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_volume"
+}
+
+func (d *NetworkVolumeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches a RunPod network volume and whether it is attached to any pod.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the network volume.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the network volume.",
+				Computed:    true,
+			},
+			"size_in_gb": schema.Int64Attribute{
+				Description: "The size of the network volume in GB.",
+				Computed:    true,
+			},
+			"data_center_id": schema.StringAttribute{
+				Description: "The ID of the data center the network volume is in.",
+				Computed:    true,
+			},
+			"in_use": schema.BoolAttribute{
+				Description: "Whether the network volume is attached to at least one pod.",
+				Computed:    true,
+			},
+			"attached_pod_ids": schema.ListAttribute{
+				Description: "The IDs of the pods the network volume is attached to.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *NetworkVolumeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NetworkVolumeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, d.client)
+
+	var data NetworkVolumeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading network volume", map[string]interface{}{"id": data.ID.ValueString()})
+
+	volume, podIDs, err := d.client.GetNetworkVolume(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to read network volume: %s", err))
+		return
+	}
+
+	data.Name = types.StringValue(volume.Name)
+	data.SizeInGb = types.Int64Value(int64(volume.Size))
+	data.DataCenterID = types.StringValue(volume.DataCenterID)
+	data.InUse = types.BoolValue(len(podIDs) > 0)
+
+	attached, diags := types.ListValueFrom(ctx, types.StringType, podIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.AttachedPodIDs = attached
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNetworkVolumeDataSource_basic(t *testing.T) {
+	volumeID := os.Getenv("RUNPOD_TEST_NETWORK_VOLUME_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if volumeID == "" {
+				t.Skip("RUNPOD_TEST_NETWORK_VOLUME_ID must be set for network volume acceptance tests")
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkVolumeDataSourceConfig(volumeID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.runpod_network_volume.test", "id", volumeID),
+					resource.TestCheckResourceAttrSet("data.runpod_network_volume.test", "size_in_gb"),
+					resource.TestCheckResourceAttrSet("data.runpod_network_volume.test", "in_use"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNetworkVolumeDataSourceConfig(id string) string {
+	return fmt.Sprintf(`
+data "runpod_network_volume" "test" {
+  id = %[1]q
+}
+`, id)
+}
+
+func TestGetNetworkVolume_attachedPods(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"myself":{
+			"networkVolumes":[{"id":"vol-1","name":"data","size":100,"dataCenterId":"EU-RO-1"},{"id":"vol-2","name":"other","size":10,"dataCenterId":"US-TX-3"}],
+			"pods":[{"id":"pod-a","networkVolumeId":"vol-1"},{"id":"pod-b","networkVolumeId":null},{"id":"pod-c","networkVolumeId":"vol-1"}]
+		}}}`)
+	})
+
+	volume, podIDs, err := client.GetNetworkVolume(context.Background(), "vol-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if volume.Name != "data" || volume.Size != 100 {
+		t.Errorf("unexpected volume: %+v", volume)
+	}
+	if !reflect.DeepEqual(podIDs, []string{"pod-a", "pod-c"}) {
+		t.Errorf("unexpected attached pods: %v", podIDs)
+	}
+
+	if _, _, err := client.GetNetworkVolume(context.Background(), "vol-3"); err == nil {
+		t.Error("expected not found error")
+	}
+}
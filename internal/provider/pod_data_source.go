@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure interface compliance
+var _ datasource.DataSource = &PodDataSource{}
+
+func NewPodDataSource() datasource.DataSource {
+	return &PodDataSource{}
+}
+
+// PodDataSource defines the data source implementation
+type PodDataSource struct {
+	client *Client
+}
+
+// PodDataSourceModel describes the data source data model
+type PodDataSourceModel struct {
+	ID                types.String  `tfsdk:"id"`
+	PodID             types.String  `tfsdk:"pod_id"`
+	Name              types.String  `tfsdk:"name"`
+	ImageName         types.String  `tfsdk:"image_name"`
+	GpuTypeID         types.String  `tfsdk:"gpu_type_id"`
+	GpuCount          types.Int64   `tfsdk:"gpu_count"`
+	VolumeInGb        types.Int64   `tfsdk:"volume_in_gb"`
+	ContainerDiskInGb types.Int64   `tfsdk:"container_disk_in_gb"`
+	DesiredStatus     types.String  `tfsdk:"desired_status"`
+	CostPerHr         types.Float64 `tfsdk:"cost_per_hr"`
+	Ports             types.String  `tfsdk:"ports"`
+	VolumeMountPath   types.String  `tfsdk:"volume_mount_path"`
+	NetworkVolumeID   types.String  `tfsdk:"network_volume_id"`
+	MachineID         types.String  `tfsdk:"machine_id"`
+	PodHostID         types.String  `tfsdk:"pod_host_id"`
+	ConsoleURL        types.String  `tfsdk:"console_url"`
+	StatusMessage     types.String  `tfsdk:"status_message"`
+	ActualCloudType   types.String  `tfsdk:"actual_cloud_type"`
+	Location          types.String  `tfsdk:"location"`
+	PortMappings      types.Map     `tfsdk:"port_mappings"`
+	RuntimePorts      types.List    `tfsdk:"runtime_ports"`
+	PublicIP          types.String  `tfsdk:"public_ip"`
+	CPUUtilPercent    types.Int64   `tfsdk:"cpu_util_percent"`
+	MemoryUtilPercent types.Int64   `tfsdk:"memory_util_percent"`
+	UptimeInSeconds   types.Int64   `tfsdk:"uptime_in_seconds"`
+}
+
+func (d *PodDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pod"
+}
+
+func (d *PodDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches an existing RunPod pod, including pods not managed by Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the pod.",
+				Computed:    true,
+			},
+			"pod_id": schema.StringAttribute{
+				Description: "The ID of the pod to look up.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the pod.",
+				Computed:    true,
+			},
+			"image_name": schema.StringAttribute{
+				Description: "The Docker image the pod runs.",
+				Computed:    true,
+			},
+			"gpu_type_id": schema.StringAttribute{
+				Description: "The GPU type the pod is deployed on. Null when RunPod does not report it.",
+				Computed:    true,
+			},
+			"gpu_count": schema.Int64Attribute{
+				Description: "The number of GPUs.",
+				Computed:    true,
+			},
+			"volume_in_gb": schema.Int64Attribute{
+				Description: "The size of the pod volume in GB.",
+				Computed:    true,
+			},
+			"container_disk_in_gb": schema.Int64Attribute{
+				Description: "The size of the container disk in GB.",
+				Computed:    true,
+			},
+			"desired_status": schema.StringAttribute{
+				Description: "The status RunPod is driving the pod towards (e.g., RUNNING, EXITED).",
+				Computed:    true,
+			},
+			"cost_per_hr": schema.Float64Attribute{
+				Description: "The hourly cost of the pod in USD.",
+				Computed:    true,
+			},
+			"ports": schema.StringAttribute{
+				Description: "The ports the pod exposes (e.g., '8888/http,22/tcp').",
+				Computed:    true,
+			},
+			"volume_mount_path": schema.StringAttribute{
+				Description: "Where the pod volume is mounted.",
+				Computed:    true,
+			},
+			"network_volume_id": schema.StringAttribute{
+				Description: "The ID of the attached network volume. Null when none is attached.",
+				Computed:    true,
+			},
+			"machine_id": schema.StringAttribute{
+				Description: "The ID of the machine the pod runs on.",
+				Computed:    true,
+			},
+			"pod_host_id": schema.StringAttribute{
+				Description: "The host ID of the pod. Null when RunPod does not report it.",
+				Computed:    true,
+			},
+			"console_url": schema.StringAttribute{
+				Description: "Link to the pod in the RunPod web console.",
+				Computed:    true,
+			},
+			"status_message": schema.StringAttribute{
+				Description: "The latest status message reported by RunPod.",
+				Computed:    true,
+			},
+			"actual_cloud_type": schema.StringAttribute{
+				Description: "The cloud the pod is deployed on (SECURE or COMMUNITY).",
+				Computed:    true,
+			},
+			"location": schema.StringAttribute{
+				Description: "The location of the machine the pod runs on.",
+				Computed:    true,
+			},
+			"port_mappings": schema.MapAttribute{
+				Description: "Public port mapped to each exposed private port, keyed by the private port. Empty until the pod is running.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"runtime_ports": schema.ListNestedAttribute{
+				Description: "Ports of the running pod, public and private. Empty until the pod is running.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip": schema.StringAttribute{
+							Description: "The IP address the port is reachable on.",
+							Computed:    true,
+						},
+						"public_port": schema.Int64Attribute{
+							Description: "The port on ip that maps to private_port.",
+							Computed:    true,
+						},
+						"private_port": schema.Int64Attribute{
+							Description: "The port inside the container.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The protocol of the port (http or tcp).",
+							Computed:    true,
+						},
+						"is_public": schema.BoolAttribute{
+							Description: "Whether ip is a public IP address.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"public_ip": schema.StringAttribute{
+				Description: "The public IP address of the pod. Null until the pod is running or when no port is public.",
+				Computed:    true,
+			},
+			"cpu_util_percent": schema.Int64Attribute{
+				Description: "The container's CPU utilization in percent. Null while the pod is not running.",
+				Computed:    true,
+			},
+			"memory_util_percent": schema.Int64Attribute{
+				Description: "The container's memory utilization in percent. Null while the pod is not running.",
+				Computed:    true,
+			},
+			"uptime_in_seconds": schema.Int64Attribute{
+				Description: "How long the container has been running, in seconds. Null while the pod is not running.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *PodDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PodDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, d.client)
+
+	var data PodDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading pod", map[string]interface{}{"id": data.PodID.ValueString()})
+
+	pod, err := d.client.GetPod(ctx, data.PodID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to read pod: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(pod.ID)
+	data.Name = types.StringValue(pod.Name)
+	data.ImageName = types.StringValue(pod.ImageName)
+	data.GpuTypeID = optionalString(podGpuTypeID(pod))
+	data.GpuCount = types.Int64Value(int64(pod.GpuCount))
+	data.VolumeInGb = types.Int64Value(int64(pod.VolumeInGb))
+	data.ContainerDiskInGb = types.Int64Value(int64(pod.ContainerDiskInGb))
+	data.DesiredStatus = types.StringValue(pod.DesiredStatus)
+	data.CostPerHr = types.Float64Value(pod.CostPerHr)
+	data.Ports = types.StringValue(pod.Ports)
+	data.VolumeMountPath = types.StringValue(pod.VolumeMountPath)
+	data.NetworkVolumeID = optionalString(pod.NetworkVolumeID)
+	data.MachineID = types.StringValue(pod.MachineID)
+	data.PodHostID = types.StringNull()
+	if pod.Machine != nil {
+		data.PodHostID = optionalString(pod.Machine.PodHostID)
+	}
+	data.ConsoleURL = types.StringValue(podConsoleURL(pod.ID))
+	data.StatusMessage = optionalString(pod.LastStatusChange)
+	data.ActualCloudType = optionalString(podCloudType(pod))
+	data.Location = optionalString(podLocation(pod))
+	data.PortMappings = podPortMappings(pod)
+	data.RuntimePorts = podRuntimePorts(pod)
+	data.PublicIP = podPublicIP(pod)
+	data.CPUUtilPercent, data.MemoryUtilPercent = podUtilization(pod)
+	data.UptimeInSeconds = podUptime(pod)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPodDataSource_basic(t *testing.T) {
+	podID := os.Getenv("RUNPOD_TEST_POD_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if podID == "" {
+				t.Skip("RUNPOD_TEST_POD_ID must be set for pod data source acceptance tests")
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPodDataSourceConfig(podID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.runpod_pod.test", "id", podID),
+					resource.TestCheckResourceAttrSet("data.runpod_pod.test", "name"),
+					resource.TestCheckResourceAttrSet("data.runpod_pod.test", "desired_status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPodDataSourceConfig(id string) string {
+	return fmt.Sprintf(`
+data "runpod_pod" "test" {
+  pod_id = %[1]q
+}
+`, id)
+}
+
+func TestPodDataSource_read(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"pod":{"id":"pod-1","name":"external","imageName":"runpod/base","gpuCount":1,
+			"desiredStatus":"RUNNING","costPerHr":0.34,"ports":"22/tcp","machineId":"m-1",
+			"machine":{"podHostId":"pod-1-64410ab1","gpuTypeId":"NVIDIA RTX A4000","secureCloud":true},
+			"runtime":{"ports":[{"ip":"203.0.113.7","isIpPublic":true,"privatePort":22,"publicPort":40022,"type":"tcp"}]}}}`), nil
+	})
+	d := &PodDataSource{client: client}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	raw := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		raw[name] = tftypes.NewValue(attrType, nil)
+	}
+	raw["pod_id"] = tftypes.NewValue(tftypes.String, "pod-1")
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, raw)}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data PodDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.ID.ValueString() != "pod-1" || data.PodHostID.ValueString() != "pod-1-64410ab1" {
+		t.Errorf("unexpected pod: %+v", data)
+	}
+	if data.GpuTypeID.ValueString() != "NVIDIA RTX A4000" || data.ActualCloudType.ValueString() != "SECURE" {
+		t.Errorf("unexpected GPU type or cloud: %s, %s", data.GpuTypeID, data.ActualCloudType)
+	}
+	if data.PublicIP.ValueString() != "203.0.113.7" || len(data.RuntimePorts.Elements()) != 1 {
+		t.Errorf("unexpected ports: %s, %s", data.PublicIP, data.RuntimePorts)
+	}
+	if !data.NetworkVolumeID.IsNull() {
+		t.Errorf("expected no network volume, got %s", data.NetworkVolumeID)
+	}
+}
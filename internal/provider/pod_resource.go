@@ -2,18 +2,35 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -25,6 +42,40 @@ import (
 // Ensure interface compliance
 var _ resource.Resource = &PodResource{}
 var _ resource.ResourceWithImportState = &PodResource{}
+var _ resource.ResourceWithModifyPlan = &PodResource{}
+var _ resource.ResourceWithConfigValidators = &PodResource{}
+var _ resource.ResourceWithValidateConfig = &PodResource{}
+
+const defaultPodStartTimeout = 10 * time.Minute
+
+// defaultReadTimeout, defaultUpdateTimeout and defaultDeleteTimeout apply when
+// the timeouts block does not set read, update or delete
+const (
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+)
+
+const consoleURLPrefix = "https://www.runpod.io/console/pods/"
+
+// podPollInterval is how often a pod is polled while waiting for it to start
+var podPollInterval = 10 * time.Second
+
+// maxEditAttempts bounds how many times a conflicting pod edit is retried
+const maxEditAttempts = 3
+
+// editConflictRetryDelay is how long to wait before retrying a conflicting pod edit
+var editConflictRetryDelay = 2 * time.Second
+
+// maxVolumeReadyAttempts bounds how many times a deploy is attempted while its network volume is not ready
+const maxVolumeReadyAttempts = 3
+
+// volumeReadyRetryDelay is how long to wait for a network volume before retrying a deploy
+var volumeReadyRetryDelay = 10 * time.Second
+
+// cancelledDeployTerminateTimeout bounds the best-effort termination of a pod
+// whose deploy was cancelled
+const cancelledDeployTerminateTimeout = 30 * time.Second
 
 func NewPodResource() resource.Resource {
 	return &PodResource{}
@@ -37,27 +88,97 @@ type PodResource struct {
 
 // PodResourceModel describes the resource data model
 type PodResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	ImageName         types.String `tfsdk:"image_name"`
-	GpuTypeID         types.String `tfsdk:"gpu_type_id"`
-	GpuCount          types.Int64  `tfsdk:"gpu_count"`
-	VolumeInGb        types.Int64  `tfsdk:"volume_in_gb"`
-	ContainerDiskInGb types.Int64  `tfsdk:"container_disk_in_gb"`
-	CloudType         types.String `tfsdk:"cloud_type"`
-	Ports             types.String `tfsdk:"ports"`
-	VolumeMountPath   types.String `tfsdk:"volume_mount_path"`
-	DockerArgs        types.String `tfsdk:"docker_args"`
-	Env               types.Map    `tfsdk:"env"`
-	MinVcpuCount      types.Int64  `tfsdk:"min_vcpu_count"`
-	MinMemoryInGb     types.Int64  `tfsdk:"min_memory_in_gb"`
-	NetworkVolumeID   types.String `tfsdk:"network_volume_id"`
-	TemplateID        types.String `tfsdk:"template_id"`
-	DataCenterID      types.String `tfsdk:"data_center_id"`
-	SupportPublicIP   types.Bool   `tfsdk:"support_public_ip"`
-	StartSSH          types.Bool   `tfsdk:"start_ssh"`
-	MachineID         types.String `tfsdk:"machine_id"`
-	PodHostID         types.String `tfsdk:"pod_host_id"`
+	ID                       types.String              `tfsdk:"id"`
+	Name                     types.String              `tfsdk:"name"`
+	StableName               types.String              `tfsdk:"stable_name"`
+	ImageName                types.String              `tfsdk:"image_name"`
+	FallbackImageName        types.String              `tfsdk:"fallback_image_name"`
+	DeployedImageName        types.String              `tfsdk:"deployed_image_name"`
+	GpuTypeID                types.String              `tfsdk:"gpu_type_id"`
+	GpuTypeIDs               types.List                `tfsdk:"gpu_type_ids"`
+	GpuCount                 types.Int64               `tfsdk:"gpu_count"`
+	MinAcceptableGpuCount    types.Int64               `tfsdk:"min_acceptable_gpu_count"`
+	MaxPricePerHr            types.Float64             `tfsdk:"max_price_per_hr"`
+	Interruptible            types.Bool                `tfsdk:"interruptible"`
+	BidPerGpu                types.Float64             `tfsdk:"bid_per_gpu"`
+	ActualGpuCount           types.Int64               `tfsdk:"actual_gpu_count"`
+	VolumeInGb               types.Int64               `tfsdk:"volume_in_gb"`
+	PreventDestroyWithVolume types.Bool                `tfsdk:"prevent_destroy_with_volume"`
+	ContainerDiskInGb        types.Int64               `tfsdk:"container_disk_in_gb"`
+	CloudType                types.String              `tfsdk:"cloud_type"`
+	ActualCloudType          types.String              `tfsdk:"actual_cloud_type"`
+	Location                 types.String              `tfsdk:"location"`
+	TotalDiskInGb            types.Int64               `tfsdk:"total_disk_in_gb"`
+	PersistentDiskInGb       types.Int64               `tfsdk:"persistent_disk_in_gb"`
+	Ports                    types.String              `tfsdk:"ports"`
+	ExposedPorts             types.List                `tfsdk:"exposed_ports"`
+	VolumeMountPath          types.String              `tfsdk:"volume_mount_path"`
+	DockerArgs               types.String              `tfsdk:"docker_args"`
+	Env                      types.Map                 `tfsdk:"env"`
+	PersistentEnv            types.Map                 `tfsdk:"persistent_env"`
+	MutableEnvKeys           types.Set                 `tfsdk:"mutable_env_keys"`
+	IgnoreEnvKeys            types.List                `tfsdk:"ignore_env_keys"`
+	EffectiveEnv             types.Map                 `tfsdk:"effective_env"`
+	RuntimeEnv               types.Map                 `tfsdk:"runtime_env"`
+	MinVcpuCount             types.Int64               `tfsdk:"min_vcpu_count"`
+	MinMemoryInGb            types.Int64               `tfsdk:"min_memory_in_gb"`
+	NetworkVolumeID          types.String              `tfsdk:"network_volume_id"`
+	InlineNetworkVolume      *InlineNetworkVolumeModel `tfsdk:"inline_network_volume"`
+	Timeouts                 timeouts.Value            `tfsdk:"timeouts"`
+	InlineNetworkVolumeID    types.String              `tfsdk:"inline_network_volume_id"`
+	TemplateID               types.String              `tfsdk:"template_id"`
+	ContainerRegistryAuthID  types.String              `tfsdk:"container_registry_auth_id"`
+	DataCenterID             types.String              `tfsdk:"data_center_id"`
+	AllowedCudaVersions      types.List                `tfsdk:"allowed_cuda_versions"`
+	SupportPublicIP          types.Bool                `tfsdk:"support_public_ip"`
+	PublicIPOptional         types.Bool                `tfsdk:"public_ip_optional"`
+	PublicIPDowngraded       types.Bool                `tfsdk:"public_ip_downgraded"`
+	StartSSH                 types.Bool                `tfsdk:"start_ssh"`
+	InjectDistributedEnv     types.Bool                `tfsdk:"inject_distributed_env"`
+	WaitForRunning           types.Bool                `tfsdk:"wait_for_running"`
+	DesiredStatus            types.String              `tfsdk:"desired_status"`
+	MinUptimeSeconds         types.Int64               `tfsdk:"min_uptime_seconds"`
+	MachineID                types.String              `tfsdk:"machine_id"`
+	PodHostID                types.String              `tfsdk:"pod_host_id"`
+	ConsoleURL               types.String              `tfsdk:"console_url"`
+	ActualGpuTypeID          types.String              `tfsdk:"actual_gpu_type_id"`
+	StatusMessage            types.String              `tfsdk:"status_message"`
+	PortMappings             types.Map                 `tfsdk:"port_mappings"`
+	RuntimePorts             types.List                `tfsdk:"runtime_ports"`
+	PublicIP                 types.String              `tfsdk:"public_ip"`
+	SSHHost                  types.String              `tfsdk:"ssh_host"`
+	SSHPort                  types.Int64               `tfsdk:"ssh_port"`
+	SSHCommand               types.String              `tfsdk:"ssh_command"`
+	CPUUtilPercent           types.Int64               `tfsdk:"cpu_util_percent"`
+	MemoryUtilPercent        types.Int64               `tfsdk:"memory_util_percent"`
+	UptimeInSeconds          types.Int64               `tfsdk:"uptime_in_seconds"`
+}
+
+// ExposedPortModel describes one entry of exposed_ports
+type ExposedPortModel struct {
+	Port     types.Int64  `tfsdk:"port"`
+	Protocol types.String `tfsdk:"protocol"`
+}
+
+// exposedPortAttrTypes are the attribute types of an exposed_ports element
+var exposedPortAttrTypes = map[string]attr.Type{
+	"port":     types.Int64Type,
+	"protocol": types.StringType,
+}
+
+// InlineNetworkVolumeModel describes a network volume created with the pod
+type InlineNetworkVolumeModel struct {
+	Name         types.String `tfsdk:"name"`
+	SizeInGb     types.Int64  `tfsdk:"size_in_gb"`
+	DataCenterID types.String `tfsdk:"data_center_id"`
+}
+
+// withTimeout bounds ctx by timeout, leaving it unbounded when timeout is zero
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
 }
 
 func (r *PodResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -79,6 +200,11 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Description: "The name of the pod.",
 				Required:    true,
 			},
+			"stable_name": schema.StringAttribute{
+				Description: "An identifier for the pod that, unlike id, stays the same when the pod is replaced. It is " +
+					"derived from name and known at plan time, so references to it do not change during a replacement.",
+				Computed: true,
+			},
 			"image_name": schema.StringAttribute{
 				Description: "The Docker image to use for the pod.",
 				Required:    true,
@@ -86,13 +212,39 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"fallback_image_name": schema.StringAttribute{
+				Description: "A Docker image to deploy instead when image_name fails to pull, e.g. because of missing registry " +
+					"credentials or an unknown tag. Pull failures reported after deploy are only detected with wait_for_running.",
+				Optional: true,
+			},
+			"deployed_image_name": schema.StringAttribute{
+				Description: "The Docker image the pod was deployed with; differs from image_name when fallback_image_name was used.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"gpu_type_id": schema.StringAttribute{
-				Description: "The ID of the GPU type to use (e.g., 'NVIDIA RTX A6000').",
-				Required:    true,
+				Description: "The ID of the GPU type to use (e.g., 'NVIDIA RTX A6000'). " +
+					"At least one of gpu_type_id and gpu_type_ids must be set; when both are, gpu_type_id is tried first.",
+				Optional: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"gpu_type_ids": schema.ListAttribute{
+				Description: "GPU types to deploy on in order of preference, after gpu_type_id when that is set. " +
+					"When no machine has capacity for a GPU type, the deploy moves on to the next one.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
 			"gpu_count": schema.Int64Attribute{
 				Description: "The number of GPUs to allocate.",
 				Optional:    true,
@@ -102,6 +254,51 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					int64validator.AtLeast(1),
 				},
 			},
+			"min_acceptable_gpu_count": schema.Int64Attribute{
+				Description: "The fewest GPUs to accept when gpu_count GPUs are not available. If set, a deploy that fails " +
+					"for lack of capacity is retried with one GPU fewer at a time down to this count. Cannot be combined " +
+					"with inject_distributed_env.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_price_per_hr": schema.Float64Attribute{
+				Description: "The most the pod may cost per hour in USD. The pod is not deployed if the current on-demand " +
+					"price of gpu_count GPUs of gpu_type_id exceeds it; spot pods are checked at their bid. The check is skipped with a warning when no price is available.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"interruptible": schema.BoolAttribute{
+				Description: "Whether to deploy a spot (interruptible) pod, bidding bid_per_gpu. Spot pods cost less " +
+					"but RunPod stops them when outbid.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"bid_per_gpu": schema.Float64Attribute{
+				Description: "The hourly bid in USD per GPU of a spot pod. Required when interruptible is true. " +
+					"The API cannot change the bid of a running pod, so changing it replaces the pod.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"actual_gpu_count": schema.Int64Attribute{
+				Description: "The number of GPUs the pod was deployed with; lower than gpu_count when min_acceptable_gpu_count was used.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 			"volume_in_gb": schema.Int64Attribute{
 				Description: "The size of the persistent volume in GB.",
 				Optional:    true,
@@ -114,6 +311,13 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					int64validator.AtLeast(0),
 				},
 			},
+			"prevent_destroy_with_volume": schema.BoolAttribute{
+				Description: "Whether to refuse to terminate the pod, including for replacement, while it has an inline volume " +
+					"(volume_in_gb > 0). Set to false and apply before destroying the pod.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"container_disk_in_gb": schema.Int64Attribute{
 				Description: "The size of the container disk in GB.",
 				Optional:    true,
@@ -142,6 +346,38 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					stringplanmodifier.RequiresReplace(),
 					stringplanmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.String{
+					portsFormat{},
+				},
+			},
+			"exposed_ports": schema.ListNestedAttribute{
+				Description: "Ports to expose as a list of objects, an alternative to ports that is easier to build " +
+					"with for expressions. Cannot be combined with ports.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"port": schema.Int64Attribute{
+							Description: "The port inside the container.",
+							Required:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
+							},
+						},
+						"protocol": schema.StringAttribute{
+							Description: "The protocol to expose the port with (http or tcp).",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("http", "tcp"),
+							},
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("ports")),
+				},
 			},
 			"volume_mount_path": schema.StringAttribute{
 				Description: "The path to mount the persistent volume.",
@@ -162,19 +398,54 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				},
 			},
 			"env": schema.MapAttribute{
-				Description: "Environment variables to set in the container.",
+				Description: "Environment variables to set in the container. Changing a key listed in mutable_env_keys " +
+					"edits the pod in place (restarting the container); changing any other key replaces the pod.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					envRequiresReplace{},
+				},
+			},
+			"effective_env": schema.MapAttribute{
+				Description: "The pod's full environment: the env of template_id, if any, overridden by the env set on the pod.",
+				Computed:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"mutable_env_keys": schema.SetAttribute{
+				Description: "Keys of env that may be added, changed or removed in place instead of replacing the pod.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"ignore_env_keys": schema.ListAttribute{
+				Description: "Keys of env and persistent_env whose values on the pod are not read back, so values RunPod " +
+					"sets for them do not show as drift. Each entry is a key or a regular expression matching the whole key.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"persistent_env": schema.MapAttribute{
+				Description: "Environment variables that persist across stop and resume, such as credentials. Unlike env, changes are applied to the existing pod in place, which restarts the container.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"runtime_env": schema.MapAttribute{
+				Description: "Environment variables whose values are templates resolved once the pod is running, " +
+					"e.g. \"http://{{public_ip}}:{{public_port:8888}}\". Supported placeholders are {{pod_id}}, {{public_ip}} " +
+					"and {{public_port:<private port>}}. Requires wait_for_running. The rendered values are applied by editing " +
+					"the pod, which restarts the container once after creation.",
 				Optional:    true,
 				ElementType: types.StringType,
 				PlanModifiers: []planmodifier.Map{
-					// Env vars cannot be changed after pod creation
+					mapplanmodifier.RequiresReplace(),
 				},
 			},
 			"min_vcpu_count": schema.Int64Attribute{
-				Description: "Minimum number of vCPUs required.",
+				Description: "Minimum number of vCPUs required. This is a lower bound used to select a machine, not an exact allocation.",
 				Optional:    true,
 			},
 			"min_memory_in_gb": schema.Int64Attribute{
-				Description: "Minimum amount of memory in GB required.",
+				Description: "Minimum amount of memory in GB required. This is a lower bound used to select a machine, not an exact allocation.",
 				Optional:    true,
 			},
 			"network_volume_id": schema.StringAttribute{
@@ -191,6 +462,50 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"allowed_cuda_versions": schema.ListAttribute{
+				Description: "CUDA versions (e.g. \"12.4\") the host driver must support. Only machines whose driver supports " +
+					"one of them are considered.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(knownCUDAVersion{}),
+				},
+			},
+			"container_registry_auth_id": schema.StringAttribute{
+				Description: "The ID of the RunPod container registry credential used to pull a private image_name. " +
+					"Read from the pod, so a credential changed outside Terraform shows as drift.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"persistent_disk_in_gb": schema.Int64Attribute{
+				Description: "The storage in GB that survives a pod restart: the attached network volume's size, or volume_in_gb " +
+					"without one. Null if the network volume cannot be read.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"total_disk_in_gb": schema.Int64Attribute{
+				Description: "The pod's total storage in GB: container_disk_in_gb plus persistent_disk_in_gb.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"inline_network_volume_id": schema.StringAttribute{
+				Description: "The ID of the network volume created from inline_network_volume.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"data_center_id": schema.StringAttribute{
 				Description: "The ID of the data center to deploy in.",
 				Optional:    true,
@@ -216,6 +531,51 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"inject_distributed_env": schema.BoolAttribute{
+				Description: "Whether to inject standard multi-GPU environment variables (WORLD_SIZE, LOCAL_WORLD_SIZE and CUDA_VISIBLE_DEVICES) derived from gpu_count. Values set in env take precedence.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"public_ip_optional": schema.BoolAttribute{
+				Description: "Whether to deploy without a public IP when support_public_ip is set but the data center has none available.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"public_ip_downgraded": schema.BoolAttribute{
+				Description: "Whether the pod was deployed without the requested public IP because of public_ip_optional.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"desired_status": schema.StringAttribute{
+				Description: "Whether the pod should be RUNNING or STOPPED. Changing it stops or resumes the pod in place; " +
+					"a stopped pod keeps its volume but releases its GPUs. Defaults to RUNNING.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("RUNNING"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("RUNNING", "STOPPED"),
+				},
+			},
+			"wait_for_running": schema.BoolAttribute{
+				Description: "Whether to wait for the pod to reach RUNNING with an active runtime before completing creation.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"min_uptime_seconds": schema.Int64Attribute{
+				Description: "Minimum container uptime in seconds required before the pod is considered ready. Only used when wait_for_running is true.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 			"machine_id": schema.StringAttribute{
 				Description: "The ID of the machine the pod is running on.",
 				Computed:    true,
@@ -230,232 +590,1427 @@ func (r *PodResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"actual_gpu_type_id": schema.StringAttribute{
+				Description: "The ID of the GPU type the pod was actually deployed on.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"port_mappings": schema.MapAttribute{
+				Description: "Public port mapped to each exposed private port, keyed by the private port, " +
+					"e.g. port_mappings[\"8888\"]. Empty until the pod is running.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"runtime_ports": schema.ListNestedAttribute{
+				Description: "Ports of the running pod, public and private. Empty until the pod is running.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip": schema.StringAttribute{
+							Description: "The IP address the port is reachable on.",
+							Computed:    true,
+						},
+						"public_port": schema.Int64Attribute{
+							Description: "The port on ip that maps to private_port.",
+							Computed:    true,
+						},
+						"private_port": schema.Int64Attribute{
+							Description: "The port inside the container.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The protocol of the port (http or tcp).",
+							Computed:    true,
+						},
+						"is_public": schema.BoolAttribute{
+							Description: "Whether ip is a public IP address.",
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_ip": schema.StringAttribute{
+				Description: "The public IP address of the pod. Null until the pod is running or when no port is public.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ssh_host": schema.StringAttribute{
+				Description: "The public IP address to connect to over SSH. Null until the pod is running or when port 22 is not exposed publicly over TCP.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ssh_port": schema.Int64Attribute{
+				Description: "The public port mapped to the pod's SSH port 22. Null when ssh_host is null.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"ssh_command": schema.StringAttribute{
+				Description: "A command to connect to the pod as root, e.g. \"ssh root@203.0.113.7 -p 40022\". Null when ssh_host is null.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cpu_util_percent": schema.Int64Attribute{
+				Description: "The container's CPU utilization in percent when last read. Null while the pod is not running.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"memory_util_percent": schema.Int64Attribute{
+				Description: "The container's memory utilization in percent when last read. Null while the pod is not running.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"uptime_in_seconds": schema.Int64Attribute{
+				Description: "How long the container had been running when last read, in seconds. Null while the pod is not running.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"actual_cloud_type": schema.StringAttribute{
+				Description: "The cloud the pod was deployed on (SECURE or COMMUNITY). Useful when cloud_type is ALL.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"location": schema.StringAttribute{
+				Description: "The human-readable location of the machine the pod runs on, as reported by RunPod.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status_message": schema.StringAttribute{
+				Description: "The most recent status message reported by RunPod for the pod, such as why it failed to start.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"console_url": schema.StringAttribute{
+				Description: "The URL of the pod in the RunPod web console.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Read:              true,
+				Update:            true,
+				Delete:            true,
+				CreateDescription: "How long create waits for the pod to become ready with wait_for_running, as a duration such as \"30m\". Defaults to 10m; \"0\" does not wait.",
+				ReadDescription:   "How long reading the pod may take, as a duration such as \"30m\". Defaults to 5m; \"0\" sets no limit.",
+				UpdateDescription: "How long resuming a stopped pod waits for it to become ready with wait_for_running, as a duration such as \"30m\". Defaults to 10m; \"0\" does not wait.",
+				DeleteDescription: "How long terminating the pod and deleting its inline network volume may take, as a duration such as \"30m\". Defaults to 10m; \"0\" sets no limit.",
+			}),
+			"inline_network_volume": schema.SingleNestedBlock{
+				Description: "A network volume to create with the pod and attach to it. The volume is deleted when the pod " +
+					"is destroyed. Cannot be combined with network_volume_id.",
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the network volume.",
+						Required:    true,
+					},
+					"size_in_gb": schema.Int64Attribute{
+						Description: "The size of the network volume in GB.",
+						Required:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"data_center_id": schema.StringAttribute{
+						Description: "The ID of the data center to create the volume in. The pod is deployed in the same data center.",
+						Required:    true,
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 	}
 }
 
-func (r *PodResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
-	}
-
-	client, ok := req.ProviderData.(*Client)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
-		)
-		return
+func (r *PodResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("gpu_type_id"),
+			path.MatchRoot("gpu_type_ids"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("inline_network_volume"),
+			path.MatchRoot("network_volume_id"),
+		),
 	}
-
-	r.client = client
 }
 
-func (r *PodResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func (r *PodResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
 	var data PodResourceModel
-
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Debug(ctx, "Creating pod", map[string]interface{}{
-		"name": data.Name.ValueString(),
-	})
+	resp.Diagnostics.Append(volumeMountDiagnostics(&data)...)
+	resp.Diagnostics.Append(interruptibleDiagnostics(&data)...)
+	resp.Diagnostics.Append(minAcceptableGpuCountDiagnostics(&data)...)
+	resp.Diagnostics.Append(inlineNetworkVolumeDiagnostics(&data)...)
 
-	// Build pod input
-	input := &PodInput{
-		Name:              data.Name.ValueString(),
-		ImageName:         data.ImageName.ValueString(),
-		GpuCount:          int(data.GpuCount.ValueInt64()),
-		VolumeInGb:        int(data.VolumeInGb.ValueInt64()),
-		ContainerDiskInGb: int(data.ContainerDiskInGb.ValueInt64()),
+	if !data.IgnoreEnvKeys.IsUnknown() {
+		_, diags := envKeyMatcher(ctx, data.IgnoreEnvKeys)
+		resp.Diagnostics.Append(diags...)
 	}
+}
 
-	// Set GPU type
-	input.GpuTypeID = data.GpuTypeID.ValueString()
-
-	if !data.CloudType.IsNull() {
-		input.CloudType = data.CloudType.ValueString()
+// interruptibleDiagnostics checks that bid_per_gpu is set, and positive,
+// exactly when interruptible is true
+func interruptibleDiagnostics(data *PodResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.Interruptible.IsUnknown() || data.BidPerGpu.IsUnknown() {
+		return diags
 	}
-	if !data.Ports.IsNull() {
-		input.Ports = data.Ports.ValueString()
+
+	switch interruptible := data.Interruptible.ValueBool(); {
+	case interruptible && data.BidPerGpu.IsNull():
+		diags.AddAttributeError(path.Root("bid_per_gpu"), "Invalid Configuration",
+			"bid_per_gpu is required when interruptible is true.")
+	case interruptible && data.BidPerGpu.ValueFloat64() <= 0:
+		diags.AddAttributeError(path.Root("bid_per_gpu"), "Invalid Configuration",
+			"bid_per_gpu must be greater than zero.")
+	case !interruptible && !data.BidPerGpu.IsNull():
+		diags.AddAttributeError(path.Root("bid_per_gpu"), "Invalid Configuration",
+			"bid_per_gpu only applies to spot pods. Set interruptible = true or remove bid_per_gpu.")
 	}
-	if !data.VolumeMountPath.IsNull() {
-		input.VolumeMountPath = data.VolumeMountPath.ValueString()
+	return diags
+}
+
+// minAcceptableGpuCountDiagnostics checks that min_acceptable_gpu_count is not
+// greater than gpu_count, and is not combined with inject_distributed_env,
+// whose values are derived from the GPU count before deploying
+func minAcceptableGpuCountDiagnostics(data *PodResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.MinAcceptableGpuCount.IsNull() || data.MinAcceptableGpuCount.IsUnknown() {
+		return diags
 	}
-	if !data.DockerArgs.IsNull() {
-		input.DockerArgs = data.DockerArgs.ValueString()
+	minGpuCount := data.MinAcceptableGpuCount.ValueInt64()
+
+	// gpu_count defaults to 1 when it is not configured
+	gpuCount := int64(1)
+	if !data.GpuCount.IsNull() {
+		gpuCount = data.GpuCount.ValueInt64()
 	}
-	if !data.Env.IsNull() {
-		envMap := make(map[string]string)
-		resp.Diagnostics.Append(data.Env.ElementsAs(ctx, &envMap, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		for k, v := range envMap {
-			input.Env = append(input.Env, EnvVar{Key: k, Value: v})
-		}
+	if !data.GpuCount.IsUnknown() && minGpuCount > gpuCount {
+		diags.AddAttributeError(path.Root("min_acceptable_gpu_count"), "Invalid Configuration",
+			fmt.Sprintf("min_acceptable_gpu_count (%d) must not be greater than gpu_count (%d).", minGpuCount, gpuCount))
 	}
-	if !data.MinVcpuCount.IsNull() {
-		input.MinVcpuCount = int(data.MinVcpuCount.ValueInt64())
+	if data.InjectDistributedEnv.ValueBool() {
+		diags.AddAttributeError(path.Root("min_acceptable_gpu_count"), "Invalid Configuration",
+			"min_acceptable_gpu_count cannot be combined with inject_distributed_env, whose values depend on the GPU count.")
 	}
-	if !data.MinMemoryInGb.IsNull() {
-		input.MinMemoryInGb = int(data.MinMemoryInGb.ValueInt64())
+	return diags
+}
+
+// inlineNetworkVolumeDiagnostics checks that the inline network volume is in
+// the pod's data_center_id, when both are set
+func inlineNetworkVolumeDiagnostics(data *PodResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	inline := data.InlineNetworkVolume
+	if inline == nil || data.DataCenterID.IsNull() || data.DataCenterID.IsUnknown() || inline.DataCenterID.IsUnknown() {
+		return diags
 	}
-	if !data.NetworkVolumeID.IsNull() {
-		input.NetworkVolumeID = data.NetworkVolumeID.ValueString()
+
+	if data.DataCenterID.ValueString() != inline.DataCenterID.ValueString() {
+		diags.AddAttributeError(path.Root("inline_network_volume").AtName("data_center_id"), "Invalid Configuration",
+			fmt.Sprintf("The inline network volume is in %q but the pod's data_center_id is %q. The volume must be in the pod's data center.",
+				inline.DataCenterID.ValueString(), data.DataCenterID.ValueString()))
 	}
-	if !data.TemplateID.IsNull() {
-		input.TemplateID = data.TemplateID.ValueString()
+	return diags
+}
+
+// volumeMountDiagnostics checks that volume_in_gb and volume_mount_path are
+// set together. A volume without a mount path cannot be used, so it is an
+// error; a mount path without a volume only has no effect, so it is a warning.
+// The mount path is also used by network volumes and may be needed by a
+// template's volume, so it is not reported when either is attached.
+func volumeMountDiagnostics(data *PodResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.VolumeInGb.IsUnknown() || data.VolumeMountPath.IsUnknown() {
+		return diags
 	}
-	if !data.DataCenterID.IsNull() {
-		input.DataCenterID = data.DataCenterID.ValueString()
+
+	hasVolume := data.VolumeInGb.ValueInt64() > 0
+	if hasVolume && !data.VolumeMountPath.IsNull() && data.VolumeMountPath.ValueString() == "" {
+		diags.AddAttributeError(path.Root("volume_mount_path"), "Invalid Configuration",
+			fmt.Sprintf("volume_in_gb is %d but volume_mount_path is empty, so the volume would not be mounted. "+
+				"Set volume_mount_path or remove it to use the default /workspace.", data.VolumeInGb.ValueInt64()))
 	}
-	if !data.SupportPublicIP.IsNull() {
-		input.SupportPublicIP = data.SupportPublicIP.ValueBool()
+
+	if !hasVolume && data.VolumeMountPath.ValueString() != "" && data.NetworkVolumeID.IsNull() &&
+		data.InlineNetworkVolume == nil && data.TemplateID.IsNull() {
+		diags.AddAttributeWarning(path.Root("volume_mount_path"), "Volume Mount Path Unused",
+			fmt.Sprintf("volume_mount_path is %q but the pod has no volume. Set volume_in_gb or attach a network volume to mount one.",
+				data.VolumeMountPath.ValueString()))
 	}
-	if !data.StartSSH.IsNull() {
-		input.StartSSH = data.StartSSH.ValueBool()
+	return diags
+}
+
+// ModifyPlan plans stable_name, and checks the number of exposed ports and
+// gpu_type_id against what RunPod accepts, so mistakes are reported at plan
+// time instead of failing the deploy
+func (r *PodResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy
+	if req.Plan.Raw.IsNull() {
+		return
 	}
 
-	// Create pod
-	pod, err := r.client.CreatePod(input)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error",
-			fmt.Sprintf("Unable to create pod: %s", err))
+	var ports types.String
+	var exposedPorts types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ports"), &ports)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("exposed_ports"), &exposedPorts)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	if spec, known := portsSpec(ctx, ports, exposedPorts); known {
+		strict := r.client != nil && r.client.strictPortLimit
+		resp.Diagnostics.Append(portCountDiagnostics(portsAttribute(exposedPorts), spec, strict)...)
+	}
 
-	// Update state from API response
-	data.ID = types.StringValue(pod.ID)
-	if pod.MachineID != "" {
-		data.MachineID = types.StringValue(pod.MachineID)
+	// stable_name follows name, so it stays known across a replacement
+	var name types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if pod.Machine != nil && pod.Machine.PodHostID != "" {
-		data.PodHostID = types.StringValue(pod.Machine.PodHostID)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("stable_name"), name)...)
+
+	// The remaining checks need the API
+	if r.client == nil {
+		return
 	}
 
-	tflog.Trace(ctx, "Created pod", map[string]interface{}{"id": pod.ID})
+	var gpuTypeID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("gpu_type_id"), &gpuTypeID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !gpuTypeID.IsNull() && !gpuTypeID.IsUnknown() {
+		resp.Diagnostics.Append(r.gpuTypeIDDiagnostics(ctx, path.Root("gpu_type_id"), gpuTypeID.ValueString())...)
+	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	var gpuTypeIDs types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("gpu_type_ids"), &gpuTypeIDs)...)
+	if resp.Diagnostics.HasError() || gpuTypeIDs.IsNull() || gpuTypeIDs.IsUnknown() {
+		return
+	}
+	for i, elem := range gpuTypeIDs.Elements() {
+		if id, ok := elem.(types.String); ok && !id.IsNull() && !id.IsUnknown() {
+			resp.Diagnostics.Append(r.gpuTypeIDDiagnostics(ctx, path.Root("gpu_type_ids").AtListIndex(i), id.ValueString())...)
+		}
+	}
 }
 
-func (r *PodResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+// gpuTypeIDDiagnostics warns on attr when id is not a known GPU type. The
+// check is skipped if the GPU type list cannot be fetched.
+func (r *PodResource) gpuTypeIDDiagnostics(ctx context.Context, attr path.Path, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	gpuTypes, err := r.client.ListGpuTypes(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to list GPU types, skipping gpu_type_id check", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return diags
+	}
+
+	for _, gt := range gpuTypes {
+		if gt.ID == id {
+			return diags
+		}
+	}
+	diags.AddAttributeWarning(attr, "Unknown GPU Type",
+		fmt.Sprintf("%q is not one of the GPU types RunPod currently offers, so the deploy is likely to fail. "+
+			"Use the runpod_gpu_types data source to look up valid IDs.", id))
+	return diags
+}
+
+func (r *PodResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PodResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, r.client)
+
 	var data PodResourceModel
 
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Debug(ctx, "Reading pod", map[string]interface{}{"id": data.ID.ValueString()})
+	tflog.Debug(ctx, "Creating pod", map[string]interface{}{
+		"name": data.Name.ValueString(),
+	})
 
-	pod, err := r.client.GetPod(data.ID.ValueString())
-	if err != nil {
-		tflog.Error(ctx, "Error reading pod", map[string]interface{}{"id": data.ID.ValueString(), "error": err.Error()})
-		// Handle deleted resources gracefully
-		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "Pod not found") {
-			tflog.Warn(ctx, "Pod not found, removing from state", map[string]interface{}{"id": data.ID.ValueString()})
-			resp.State.RemoveResource(ctx)
+	// Build pod input
+	input := &PodInput{
+		Name:              data.Name.ValueString(),
+		ImageName:         data.ImageName.ValueString(),
+		GpuCount:          int(data.GpuCount.ValueInt64()),
+		VolumeInGb:        int(data.VolumeInGb.ValueInt64()),
+		ContainerDiskInGb: int(data.ContainerDiskInGb.ValueInt64()),
+	}
+
+	minGpuCount := 0
+	if !data.MinAcceptableGpuCount.IsNull() {
+		minGpuCount = int(data.MinAcceptableGpuCount.ValueInt64())
+	}
+
+	// Set GPU types
+	gpuTypeIDs, diags := requestedGpuTypeIDs(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	input.GpuTypeID = gpuTypeIDs[0]
+	input.GpuTypeIDs = gpuTypeIDs[1:]
+
+	if !data.CloudType.IsNull() {
+		input.CloudType = data.CloudType.ValueString()
+	}
+	input.Ports, _ = portsSpec(ctx, data.Ports, data.ExposedPorts)
+	if !data.VolumeMountPath.IsNull() {
+		input.VolumeMountPath = data.VolumeMountPath.ValueString()
+	}
+	if !data.DockerArgs.IsNull() {
+		input.DockerArgs = data.DockerArgs.ValueString()
+	}
+	envMap := make(map[string]string)
+	if data.InjectDistributedEnv.ValueBool() {
+		for k, v := range distributedEnv(input.GpuCount) {
+			envMap[k] = v
+		}
+	}
+	if !data.Env.IsNull() {
+		userEnv := make(map[string]string)
+		resp.Diagnostics.Append(data.Env.ElementsAs(ctx, &userEnv, false)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-		resp.Diagnostics.AddError("Client Error",
-			fmt.Sprintf("Unable to read pod: %s", err))
+		for k, v := range userEnv {
+			envMap[k] = v
+		}
+	}
+	if !data.PersistentEnv.IsNull() {
+		persistentEnv := make(map[string]string)
+		resp.Diagnostics.Append(data.PersistentEnv.ElementsAs(ctx, &persistentEnv, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for k, v := range persistentEnv {
+			envMap[k] = v
+		}
+	}
+	for k, v := range envMap {
+		input.Env = append(input.Env, EnvVar{Key: k, Value: v})
+	}
+	if !data.MinVcpuCount.IsNull() {
+		input.MinVcpuCount = int(data.MinVcpuCount.ValueInt64())
+	}
+	if !data.MinMemoryInGb.IsNull() {
+		input.MinMemoryInGb = int(data.MinMemoryInGb.ValueInt64())
+	}
+	if !data.NetworkVolumeID.IsNull() {
+		input.NetworkVolumeID = data.NetworkVolumeID.ValueString()
+	}
+	if !data.TemplateID.IsNull() {
+		input.TemplateID = data.TemplateID.ValueString()
+	}
+	if !data.AllowedCudaVersions.IsNull() {
+		resp.Diagnostics.Append(data.AllowedCudaVersions.ElementsAs(ctx, &input.AllowedCudaVersions, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !data.ContainerRegistryAuthID.IsNull() {
+		input.RegistryAuthID = data.ContainerRegistryAuthID.ValueString()
+	}
+	if !data.DataCenterID.IsNull() {
+		input.DataCenterID = data.DataCenterID.ValueString()
+	}
+	if !data.SupportPublicIP.IsNull() {
+		input.SupportPublicIP = data.SupportPublicIP.ValueBool()
+	}
+	if !data.StartSSH.IsNull() {
+		input.StartSSH = data.StartSSH.ValueBool()
+	}
+	if data.Interruptible.ValueBool() {
+		input.BidPerGpu = data.BidPerGpu.ValueFloat64()
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultPodStartTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	waitForRunning := data.WaitForRunning.ValueBool() && createTimeout > 0
 
-	// Update state from API response - only update fields that the API returns
-	// Preserve existing state values for fields the API doesn't return
-	data.Name = types.StringValue(pod.Name)
-	data.ImageName = types.StringValue(pod.ImageName)
-	if pod.Machine != nil && pod.Machine.GpuTypeID != "" {
-		data.GpuTypeID = types.StringValue(pod.Machine.GpuTypeID)
+	runtimeEnv := make(map[string]string)
+	if !data.RuntimeEnv.IsNull() {
+		resp.Diagnostics.Append(data.RuntimeEnv.ElementsAs(ctx, &runtimeEnv, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(runtimeEnv) > 0 && !waitForRunning {
+			resp.Diagnostics.AddAttributeError(path.Root("runtime_env"), "Invalid Configuration",
+				"runtime_env is resolved from the running pod's ports and requires wait_for_running = true with a non-zero timeouts.create.")
+			return
+		}
 	}
-	// If API doesn't return GpuTypeID, preserve existing state value (don't overwrite)
 
-	data.GpuCount = types.Int64Value(int64(pod.GpuCount))
-	data.VolumeInGb = types.Int64Value(int64(pod.VolumeInGb))
-	data.ContainerDiskInGb = types.Int64Value(int64(pod.ContainerDiskInGb))
+	if !data.MaxPricePerHr.IsNull() {
+		resp.Diagnostics.Append(r.limitGpuTypesToMaxPrice(ctx, input, data.MaxPricePerHr.ValueFloat64())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	if pod.Ports != "" {
-		data.Ports = types.StringValue(pod.Ports)
+	// Reserve the pod's cost against the provider's max_total_cost_per_hr,
+	// releasing it if the deploy fails
+	reservedCost, diags := r.reservePodCost(ctx, input)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if pod.VolumeMountPath != "" {
-		data.VolumeMountPath = types.StringValue(pod.VolumeMountPath)
+	deployed := false
+	defer func() {
+		if !deployed {
+			r.client.ReleaseCost(reservedCost)
+		}
+	}()
+
+	data.InlineNetworkVolumeID = types.StringNull()
+	if inline := data.InlineNetworkVolume; inline != nil {
+		volume, err := r.client.CreateNetworkVolume(ctx, inline.Name.ValueString(), int(inline.SizeInGb.ValueInt64()), inline.DataCenterID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Unable to create inline network volume: %s", err))
+			return
+		}
+		data.InlineNetworkVolumeID = types.StringValue(volume.ID)
+		input.NetworkVolumeID = volume.ID
+		input.DataCenterID = volume.DataCenterID
+
+		// Don't leave the volume behind if no pod is deployed to own it. ctx
+		// may already be cancelled, so the cleanup uses a fresh context.
+		defer func() {
+			if !deployed {
+				cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelledDeployTerminateTimeout)
+				defer cancel()
+				resp.Diagnostics.Append(r.deleteInlineVolume(cleanupCtx, volume.ID)...)
+			}
+		}()
 	}
-	if pod.DockerArgs != "" {
-		data.DockerArgs = types.StringValue(pod.DockerArgs)
+
+	// Create pod
+	requestedGpuTypeID, requestedGpuCount := input.GpuTypeID, input.GpuCount
+	pod, err := r.createPodWithGpuTypeFallback(ctx, input, minGpuCount)
+	data.PublicIPDowngraded = types.BoolValue(false)
+	if errors.Is(err, ErrPublicIPUnavailable) && data.PublicIPOptional.ValueBool() {
+		tflog.Warn(ctx, "Public IP unavailable, retrying deploy without one", map[string]interface{}{"error": err.Error()})
+
+		input.SupportPublicIP = false
+		pod, err = r.redeployPod(ctx, input, requestedGpuTypeID, requestedGpuCount, minGpuCount)
+		if err == nil {
+			data.PublicIPDowngraded = types.BoolValue(true)
+			resp.Diagnostics.AddAttributeWarning(path.Root("support_public_ip"), "Public IP Unavailable",
+				fmt.Sprintf("Pod %s was deployed without a public IP because none was available and public_ip_optional is set.", pod.ID))
+		}
 	}
+	fallbackImage := data.FallbackImageName.ValueString()
+	if err != nil && fallbackImage != "" && isImagePullFailure(err.Error()) {
+		tflog.Warn(ctx, "Image pull failed, deploying fallback image", map[string]interface{}{"error": err.Error()})
+
+		input.ImageName = fallbackImage
+		pod, err = r.redeployPod(ctx, input, requestedGpuTypeID, requestedGpuCount, minGpuCount)
+	}
+	if err != nil {
+		if errors.Is(err, ErrTooManyPorts) {
+			resp.Diagnostics.AddAttributeError(portsAttribute(data.ExposedPorts), "Too Many Ports",
+				fmt.Sprintf("RunPod rejected the pod's %d ports (at most %d are expected to be allowed): %s",
+					len(parsePorts(input.Ports)), maxExposedPorts, err))
+			return
+		}
+		if errors.Is(err, ErrCUDAVersionUnsupported) {
+			resp.Diagnostics.AddAttributeError(path.Root("allowed_cuda_versions"), "CUDA Version Not Supported",
+				cudaVersionDetail(input.AllowedCudaVersions, err.Error()))
+			return
+		}
+		if input.RegistryAuthID != "" && isRegistryAuthFailure(err.Error()) {
+			resp.Diagnostics.AddAttributeError(path.Root("container_registry_auth_id"), "Registry Credentials Rejected",
+				registryAuthFailureDetail(input.RegistryAuthID, err.Error()))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to create pod: %s", err))
+		return
+	}
+	deployed = true
+	data.ContainerRegistryAuthID = optionalString(input.RegistryAuthID)
+	data.DeployedImageName = types.StringValue(input.ImageName)
+	data.ActualGpuCount = types.Int64Value(int64(input.GpuCount))
+	if input.GpuCount < int(data.GpuCount.ValueInt64()) {
+		resp.Diagnostics.AddAttributeWarning(path.Root("gpu_count"), "Fewer GPUs Deployed",
+			fmt.Sprintf("Only %d of the requested %d GPUs were available, so pod %s was deployed with %d GPUs.",
+				input.GpuCount, data.GpuCount.ValueInt64(), pod.ID, input.GpuCount))
+	}
+
+	if waitForRunning {
+		tflog.Debug(ctx, "Waiting for pod to start", map[string]interface{}{"id": pod.ID})
+
+		minUptime := int(data.MinUptimeSeconds.ValueInt64())
+		running, err := waitForPodRunning(ctx, r.client, pod.ID, minUptime, createTimeout)
+		if err != nil && fallbackImage != "" && input.ImageName != fallbackImage &&
+			running != nil && isImagePullFailure(running.LastStatusChange) {
+			tflog.Warn(ctx, "Image pull failed, replacing pod with fallback image", map[string]interface{}{
+				"id":     pod.ID,
+				"status": running.LastStatusChange,
+			})
+
+			if err := r.client.TerminatePod(ctx, pod.ID); err != nil {
+				resp.Diagnostics.AddError("Client Error",
+					fmt.Sprintf("Unable to terminate pod %s after its image failed to pull: %s", pod.ID, err))
+				r.saveFailedCreate(ctx, resp, &data, pod, running)
+				return
+			}
+
+			input.ImageName = fallbackImage
+			pod, err = r.redeployPod(ctx, input, requestedGpuTypeID, requestedGpuCount, minGpuCount)
+			if err != nil {
+				// The original pod is gone, so its cost reservation and
+				// inline volume are released as for a failed deploy
+				deployed = false
+				resp.Diagnostics.AddError("Client Error",
+					fmt.Sprintf("Unable to create pod with fallback image: %s", err))
+				return
+			}
+			data.DeployedImageName = types.StringValue(input.ImageName)
+			data.ActualGpuCount = types.Int64Value(int64(input.GpuCount))
+
+			running, err = waitForPodRunning(ctx, r.client, pod.ID, minUptime, createTimeout)
+		}
+		if err != nil && ctx.Err() != nil && r.terminateCancelledDeploy(ctx, pod.ID) {
+			deployed = false
+			resp.Diagnostics.AddError("Pod Creation Cancelled",
+				fmt.Sprintf("Pod %s was terminated because the apply was cancelled before it became ready: %s", pod.ID, err))
+			return
+		}
+		if err != nil {
+			detail := fmt.Sprintf("Pod %s was created but did not become ready: %s", pod.ID, err)
+			if running != nil && running.LastStatusChange != "" {
+				detail += "\n\nLast status message: " + running.LastStatusChange
+				if isCUDAVersionError(running.LastStatusChange) {
+					detail += "\n\n" + cudaVersionDetail(input.AllowedCudaVersions, running.LastStatusChange)
+				}
+				if input.RegistryAuthID != "" && isRegistryAuthFailure(running.LastStatusChange) {
+					detail += "\n\n" + registryAuthFailureDetail(input.RegistryAuthID, running.LastStatusChange)
+				}
+			}
+			resp.Diagnostics.AddError("Pod Not Ready", detail)
+			r.saveFailedCreate(ctx, resp, &data, pod, running)
+			return
+		}
+		pod = running
+
+		if len(runtimeEnv) > 0 {
+			running, err := r.applyRuntimeEnv(ctx, pod, runtimeEnv, minUptime, createTimeout)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Apply Runtime Env",
+					fmt.Sprintf("Pod %s was created but runtime_env could not be applied: %s", pod.ID, err))
+				r.saveFailedCreate(ctx, resp, &data, pod, running)
+				return
+			}
+			pod = running
+		}
+	}
+
+	if data.DesiredStatus.ValueString() == "STOPPED" {
+		tflog.Debug(ctx, "Stopping pod after create", map[string]interface{}{"id": pod.ID})
+		if _, err := r.client.StopPod(ctx, pod.ID); err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Pod %s was created but could not be stopped: %s", pod.ID, err))
+			r.saveFailedCreate(ctx, resp, &data, pod, pod)
+			return
+		}
+	}
+
+	// Update state from API response
+	data.ID = types.StringValue(pod.ID)
+	data.ConsoleURL = types.StringValue(podConsoleURL(pod.ID))
+	data.StableName = data.Name
 	if pod.MachineID != "" {
 		data.MachineID = types.StringValue(pod.MachineID)
 	}
 	if pod.Machine != nil && pod.Machine.PodHostID != "" {
 		data.PodHostID = types.StringValue(pod.Machine.PodHostID)
 	}
-
-	// The following fields are not returned by the API, so preserve state values:
-	// - CloudType: already preserved from state (loaded above)
-	// - SupportPublicIP: already preserved from state (loaded above)
-	// - StartSSH: already preserved from state (loaded above)
-	// - Env: already preserved from state (loaded above)
-	// - MinVcpuCount: already preserved from state (loaded above)
-	// - MinMemoryInGb: already preserved from state (loaded above)
-	// - NetworkVolumeID: already preserved from state (loaded above)
-	// - TemplateID: already preserved from state (loaded above)
-	// - DataCenterID: already preserved from state (loaded above)
-
-	// Handle cloud_type - set default if not in state
-	if data.CloudType.IsNull() || data.CloudType.IsUnknown() {
-		data.CloudType = types.StringValue("ALL")
+	if actual := podGpuTypeID(pod); actual != "" {
+		data.ActualGpuTypeID = types.StringValue(actual)
+		resp.Diagnostics.Append(gpuTypeMismatchDiagnostics(gpuTypeIDs, actual)...)
+	} else {
+		data.ActualGpuTypeID = types.StringNull()
 	}
+	data.StatusMessage = optionalString(pod.LastStatusChange)
+	data.ActualCloudType = optionalString(podCloudType(pod))
+	data.Location = optionalString(podLocation(pod))
+	data.PortMappings = podPortMappings(pod)
+	data.RuntimePorts = podRuntimePorts(pod)
+	data.PublicIP = podPublicIP(pod)
+	data.SSHHost, data.SSHPort, data.SSHCommand = podSSH(pod)
+	data.CPUUtilPercent, data.MemoryUtilPercent = podUtilization(pod)
+	data.UptimeInSeconds = podUptime(pod)
+	data.EffectiveEnv = r.effectiveEnv(ctx, data.TemplateID, pod, &resp.Diagnostics)
+	data.TotalDiskInGb, data.PersistentDiskInGb = r.podDiskSizes(ctx, &data, pod)
+	resp.Diagnostics.Append(diskRoundingDiagnostics(input, pod)...)
+	if pod.ImageName != "" && !imagesEquivalent(pod.ImageName, input.ImageName) {
+		data.DeployedImageName = types.StringValue(pod.ImageName)
+		resp.Diagnostics.Append(imageOverrideDiagnostics(input.ImageName, pod.ImageName, input.TemplateID)...)
+	}
+	if input.ImageName != data.ImageName.ValueString() {
+		resp.Diagnostics.AddAttributeWarning(path.Root("image_name"), "Fallback Image Deployed",
+			fmt.Sprintf("Image %q failed to pull, so pod %s was deployed with fallback image %q.",
+				data.ImageName.ValueString(), pod.ID, input.ImageName))
+	}
+
+	tflog.Trace(ctx, "Created pod", map[string]interface{}{"id": pod.ID})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *PodResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan, state PodResourceModel
+// saveFailedCreate saves a pod that was deployed but failed a later step of
+// Create to state, so it is tainted and replaced rather than leaked. The last
+// observed pod, if any, is used for the status message.
+func (r *PodResource) saveFailedCreate(ctx context.Context, resp *resource.CreateResponse, data *PodResourceModel, pod, last *Pod) {
+	data.ID = types.StringValue(pod.ID)
+	data.ConsoleURL = types.StringValue(podConsoleURL(pod.ID))
+	data.StableName = data.Name
+	data.MachineID = types.StringValue(pod.MachineID)
+	data.PodHostID = types.StringNull()
+	data.ActualGpuTypeID = types.StringNull()
+	data.StatusMessage = types.StringNull()
+	data.ActualCloudType = types.StringNull()
+	data.Location = types.StringNull()
+	data.TotalDiskInGb = types.Int64Null()
+	data.PersistentDiskInGb = types.Int64Null()
+	data.PortMappings = podPortMappings(pod)
+	data.RuntimePorts = podRuntimePorts(pod)
+	data.PublicIP = podPublicIP(pod)
+	data.SSHHost, data.SSHPort, data.SSHCommand = podSSH(pod)
+	data.CPUUtilPercent = types.Int64Null()
+	data.MemoryUtilPercent = types.Int64Null()
+	data.UptimeInSeconds = types.Int64Null()
+	data.EffectiveEnv = types.MapNull(types.StringType)
+	if last != nil {
+		data.StatusMessage = optionalString(last.LastStatusChange)
+	}
 
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
-		return
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// applyRuntimeEnv renders runtime_env templates against the running pod, adds
+// the results to the pod's env and waits for the restarted pod to be ready
+func (r *PodResource) applyRuntimeEnv(ctx context.Context, pod *Pod, templates map[string]string, minUptime int, timeout time.Duration) (*Pod, error) {
+	rendered := make(map[string]string, len(templates))
+	for key, tmpl := range templates {
+		value, err := renderRuntimeEnv(tmpl, pod)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		rendered[key] = value
 	}
 
-	tflog.Debug(ctx, "Updating pod", map[string]interface{}{
-		"id": state.ID.ValueString(),
+	tflog.Debug(ctx, "Applying runtime env", map[string]interface{}{"id": pod.ID})
+
+	_, err := editPodWithRetry(ctx, r.client, pod.ID, func(current *Pod) *PodEditInput {
+		input := NewPodEditInput(current)
+		for key, value := range rendered {
+			input.Env = setEnvVar(input.Env, key, value)
+		}
+		return input
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	// RunPod has limited update capabilities - most changes require recreation
-	// For now, we just update the name if possible (though this may not be supported)
-	// Most fields use RequiresReplace so Terraform will recreate the resource
+	return waitForPodRunning(ctx, r.client, pod.ID, minUptime, timeout)
+}
 
-	// Preserve computed fields
-	plan.ID = state.ID
-	plan.MachineID = state.MachineID
-	plan.PodHostID = state.PodHostID
+// reservePodCost adds the pod's estimated hourly cost to the client's running
+// total when the provider sets max_total_cost_per_hr, and returns the amount
+// reserved. Pods whose GPU price is unknown are not counted.
+func (r *PodResource) reservePodCost(ctx context.Context, input *PodInput) (float64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if r.client.budget == nil {
+		return 0, diags
+	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	cost, ok, err := r.estimatePodCost(ctx, input)
+	if err != nil || !ok {
+		reason := "the API reported no price"
+		if err != nil {
+			reason = err.Error()
+		}
+		diags.AddWarning("Pod Cost Unknown",
+			fmt.Sprintf("Unable to estimate the cost of pod %q, so it is not counted towards max_total_cost_per_hr: %s", input.Name, reason))
+		return 0, diags
+	}
+
+	if err := r.client.ReserveCost(cost); err != nil {
+		diags.AddError("Cost Limit Exceeded",
+			fmt.Sprintf("Pod %q was not deployed: %s. Raise max_total_cost_per_hr or deploy fewer pods.", input.Name, err))
+		return 0, diags
+	}
+	return cost, diags
 }
 
-func (r *PodResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data PodResourceModel
+// checkMaxPrice fails when the pod's current on-demand price exceeds
+// max_price_per_hr. Without a price the check is skipped with a warning.
+func (r *PodResource) checkMaxPrice(ctx context.Context, input *PodInput, maxPrice float64) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
+	cost, ok, err := r.estimatePodCost(ctx, input)
+	if err != nil || !ok {
+		reason := "the API reported no price"
+		if err != nil {
+			reason = err.Error()
+		}
+		diags.AddAttributeWarning(path.Root("max_price_per_hr"), "Price Check Skipped",
+			fmt.Sprintf("Unable to read the current price of pod %q, so max_price_per_hr was not checked: %s", input.Name, reason))
+		return diags
 	}
 
-	tflog.Debug(ctx, "Terminating pod", map[string]interface{}{
-		"id": data.ID.ValueString(),
-	})
+	if cost > maxPrice {
+		diags.AddAttributeError(path.Root("max_price_per_hr"), "Price Ceiling Exceeded",
+			fmt.Sprintf("Pod %q was not deployed: %d x %s currently costs $%.3f/hr, above max_price_per_hr of $%.3f/hr.",
+				input.Name, input.GpuCount, input.GpuTypeID, cost, maxPrice))
+	}
+	return diags
+}
 
-	err := r.client.TerminatePod(data.ID.ValueString())
-	if err != nil {
-		// Ignore "not found" errors during delete
-		if strings.Contains(err.Error(), "not found") {
-			return
+// limitGpuTypesToMaxPrice drops the pod's GPU types whose current price
+// exceeds max_price_per_hr, failing only when none is left
+func (r *PodResource) limitGpuTypesToMaxPrice(ctx context.Context, input *PodInput, maxPrice float64) diag.Diagnostics {
+	var diags, rejected diag.Diagnostics
+	var within []string
+	for _, id := range input.gpuTypeIDs() {
+		candidate := *input
+		candidate.GpuTypeID = id
+		candidate.GpuTypeIDs = nil
+
+		checked := r.checkMaxPrice(ctx, &candidate, maxPrice)
+		if checked.HasError() {
+			rejected.Append(checked...)
+			continue
+		}
+		diags.Append(checked...)
+		within = append(within, id)
+	}
+
+	if len(within) == 0 {
+		diags.Append(rejected...)
+		return diags
+	}
+	if len(rejected) > 0 {
+		diags.AddAttributeWarning(path.Root("max_price_per_hr"), "GPU Types Skipped",
+			fmt.Sprintf("Pod %q will only be deployed on %s; the other GPU types currently cost more than max_price_per_hr of $%.3f/hr.",
+				input.Name, strings.Join(within, ", "), maxPrice))
+	}
+	input.GpuTypeID = within[0]
+	input.GpuTypeIDs = within[1:]
+	return diags
+}
+
+// estimatePodCost returns the pod's estimated hourly cost, and false when no
+// price is available. A spot pod costs at most its bid; otherwise the current
+// on-demand price of its GPU types is used. With fallback GPU types the
+// highest price is used, since any may be deployed.
+func (r *PodResource) estimatePodCost(ctx context.Context, input *PodInput) (float64, bool, error) {
+	if input.BidPerGpu > 0 {
+		return input.BidPerGpu * float64(input.GpuCount), true, nil
+	}
+
+	var cost float64
+	found := false
+	for _, id := range input.gpuTypeIDs() {
+		gpuType, err := r.client.FindGpuType(ctx, id)
+		if err != nil {
+			return 0, false, err
+		}
+		if price, ok := gpuType.PricePerGpu(input.CloudType); ok {
+			cost = max(cost, price*float64(input.GpuCount))
+			found = true
+		}
+	}
+	return cost, found, nil
+}
+
+// createPodWithGpuTypeFallback deploys the pod on the first of its GPU types,
+// in order of preference, that has capacity. Each type is tried down to
+// minGpuCount GPUs before moving on to the next. The type and count deployed
+// are left in input.GpuTypeID and input.GpuCount.
+func (r *PodResource) createPodWithGpuTypeFallback(ctx context.Context, input *PodInput, minGpuCount int) (*Pod, error) {
+	gpuTypeIDs := input.gpuTypeIDs()
+	gpuCount := input.GpuCount
+	for i, id := range gpuTypeIDs {
+		input.GpuTypeID = id
+		input.GpuCount = gpuCount
+
+		pod, err := r.createPodWithGpuFallback(ctx, input, minGpuCount)
+		if err == nil || i == len(gpuTypeIDs)-1 || !isCapacityError(err) {
+			return pod, err
+		}
+
+		tflog.Warn(ctx, "No capacity for GPU type, retrying deploy with the next preference", map[string]interface{}{
+			"gpu_type_id": gpuTypeIDs[i+1],
+			"error":       err.Error(),
+		})
+	}
+	return r.createPodWithGpuFallback(ctx, input, minGpuCount)
+}
+
+// redeployPod deploys the pod again after an earlier deploy failed, starting
+// over from the requested GPU type and count that the earlier GPU fallbacks
+// left changed in input
+func (r *PodResource) redeployPod(ctx context.Context, input *PodInput, gpuTypeID string, gpuCount, minGpuCount int) (*Pod, error) {
+	input.GpuTypeID = gpuTypeID
+	input.GpuCount = gpuCount
+	return r.createPodWithGpuTypeFallback(ctx, input, minGpuCount)
+}
+
+// createPodWithGpuFallback deploys the pod, retrying with one GPU fewer at a
+// time down to minGpuCount while the deploy fails for lack of capacity. The
+// count deployed is left in input.GpuCount.
+func (r *PodResource) createPodWithGpuFallback(ctx context.Context, input *PodInput, minGpuCount int) (*Pod, error) {
+	for {
+		pod, err := r.createPod(ctx, input)
+		if err == nil || input.GpuCount <= minGpuCount || !isCapacityError(err) {
+			return pod, err
+		}
+
+		tflog.Warn(ctx, "Not enough GPUs available, retrying deploy with fewer", map[string]interface{}{
+			"gpu_count": input.GpuCount - 1,
+			"error":     err.Error(),
+		})
+		input.GpuCount--
+	}
+}
+
+// fewerGpusAccepted reports whether gpuCount is a reduced count that
+// min_acceptable_gpu_count allows for the pod
+func fewerGpusAccepted(data *PodResourceModel, gpuCount int) bool {
+	if data.MinAcceptableGpuCount.IsNull() || data.GpuCount.IsNull() {
+		return false
+	}
+	return int64(gpuCount) >= data.MinAcceptableGpuCount.ValueInt64() && int64(gpuCount) < data.GpuCount.ValueInt64()
+}
+
+// createPod deploys the pod, as a spot pod when it has a bid. When a network
+// volume is attached the deploy is retried while the volume is not ready,
+// which happens when the volume was created earlier in the same apply.
+func (r *PodResource) createPod(ctx context.Context, input *PodInput) (*Pod, error) {
+	deploy := r.client.CreatePod
+	if input.BidPerGpu > 0 {
+		deploy = r.client.CreateInterruptiblePod
+	}
+
+	for attempt := 1; ; attempt++ {
+		pod, err := deploy(ctx, input)
+		if err == nil && ctx.Err() != nil {
+			// The apply was cancelled after the pod was deployed; don't leave
+			// a billed pod behind that is not recorded in state
+			r.terminateCancelledDeploy(ctx, pod.ID)
+			return nil, ctx.Err()
+		}
+		if err == nil || input.NetworkVolumeID == "" || !isVolumeNotReadyError(err) || attempt >= maxVolumeReadyAttempts {
+			return pod, err
+		}
+
+		tflog.Warn(ctx, "Network volume not ready, retrying deploy", map[string]interface{}{
+			"network_volume_id": input.NetworkVolumeID,
+			"attempt":           attempt,
+			"error":             err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(volumeReadyRetryDelay):
+		}
+	}
+}
+
+// terminateCancelledDeploy makes a best-effort attempt to terminate a pod
+// whose deploy was cancelled. ctx is already done, so the request uses a fresh
+// short-lived context.
+func (r *PodResource) terminateCancelledDeploy(ctx context.Context, id string) bool {
+	terminateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelledDeployTerminateTimeout)
+	defer cancel()
+
+	if err := r.client.TerminatePod(terminateCtx, id); err != nil {
+		tflog.Error(ctx, "Unable to terminate pod after its deploy was cancelled, terminate it manually", map[string]interface{}{
+			"id":    id,
+			"error": err.Error(),
+		})
+		return false
+	}
+
+	tflog.Warn(ctx, "Terminated pod after its deploy was cancelled", map[string]interface{}{"id": id})
+	return true
+}
+
+func (r *PodResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, r.client)
+
+	var data PodResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, readTimeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Reading pod", map[string]interface{}{"id": data.ID.ValueString()})
+
+	pod, err := r.client.GetPodBatched(ctx, data.ID.ValueString())
+	if err != nil {
+		tflog.Error(ctx, "Error reading pod", map[string]interface{}{"id": data.ID.ValueString(), "error": err.Error()})
+		// Handle deleted resources gracefully
+		if IsNotFound(err) {
+			tflog.Warn(ctx, "Pod not found, removing from state", map[string]interface{}{"id": data.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to read pod: %s", err))
+		return
+	}
+
+	// Update state from API response - only update fields that the API returns
+	// Preserve existing state values for fields the API doesn't return
+	data.Name = types.StringValue(pod.Name)
+	// A pod still running the image it was deployed with satisfies image_name,
+	// even when that is the fallback image or one set by the template. Images
+	// are compared normalized, since the API may add an implicit :latest tag.
+	if pod.ImageName != "" {
+		if !imagesEquivalent(pod.ImageName, data.ImageName.ValueString()) &&
+			!imagesEquivalent(pod.ImageName, data.DeployedImageName.ValueString()) &&
+			(data.FallbackImageName.IsNull() || !imagesEquivalent(pod.ImageName, data.FallbackImageName.ValueString())) {
+			data.ImageName = types.StringValue(pod.ImageName)
+		}
+		data.DeployedImageName = types.StringValue(pod.ImageName)
+	}
+	// gpu_type_id and gpu_type_ids keep the requested types; the deployed type
+	// is exposed as actual_gpu_type_id. Imported pods have no request, so use
+	// the actual type.
+	if actual := podGpuTypeID(pod); actual != "" {
+		if (data.GpuTypeID.IsNull() || data.GpuTypeID.ValueString() == "") && data.GpuTypeIDs.IsNull() {
+			data.GpuTypeID = types.StringValue(actual)
+		}
+		data.ActualGpuTypeID = types.StringValue(actual)
+		requested, diags := requestedGpuTypeIDs(ctx, &data)
+		if !diags.HasError() {
+			resp.Diagnostics.Append(gpuTypeMismatchDiagnostics(requested, actual)...)
+		}
+	}
+	// If API doesn't return GpuTypeID, preserve existing state value (don't overwrite)
+
+	// A pod always has GPUs and a container disk, so a zero means the API left
+	// the field out and the state value is kept. A pod deployed with fewer GPUs
+	// because of min_acceptable_gpu_count still satisfies gpu_count.
+	if pod.GpuCount > 0 {
+		if !fewerGpusAccepted(&data, pod.GpuCount) {
+			data.GpuCount = types.Int64Value(int64(pod.GpuCount))
+		}
+		data.ActualGpuCount = types.Int64Value(int64(pod.GpuCount))
+	}
+	data.VolumeInGb = types.Int64Value(int64(pod.VolumeInGb))
+	if pod.ContainerDiskInGb > 0 {
+		data.ContainerDiskInGb = types.Int64Value(int64(pod.ContainerDiskInGb))
+	}
+
+	// Keep the configured ports when the API only reformats them, so the
+	// string round-trips exactly. Drift is reported in whichever form is set.
+	if configured, _ := portsSpec(ctx, data.Ports, data.ExposedPorts); pod.Ports != "" && !portsEquivalent(configured, pod.Ports) {
+		if !data.ExposedPorts.IsNull() {
+			data.ExposedPorts = exposedPortsValue(pod.Ports)
+		} else {
+			data.Ports = types.StringValue(pod.Ports)
+		}
+	}
+	if pod.VolumeMountPath != "" {
+		data.VolumeMountPath = types.StringValue(pod.VolumeMountPath)
+	}
+	if pod.DockerArgs != "" {
+		data.DockerArgs = types.StringValue(pod.DockerArgs)
+	}
+	if pod.MachineID != "" {
+		data.MachineID = types.StringValue(pod.MachineID)
+	}
+	if pod.Machine != nil && pod.Machine.PodHostID != "" {
+		data.PodHostID = types.StringValue(pod.Machine.PodHostID)
+	}
+	data.ConsoleURL = types.StringValue(podConsoleURL(pod.ID))
+	data.StableName = data.Name
+	data.StatusMessage = optionalString(pod.LastStatusChange)
+	data.ActualCloudType = optionalString(podCloudType(pod))
+	data.Location = optionalString(podLocation(pod))
+	data.PortMappings = podPortMappings(pod)
+	data.RuntimePorts = podRuntimePorts(pod)
+	data.PublicIP = podPublicIP(pod)
+	data.SSHHost, data.SSHPort, data.SSHCommand = podSSH(pod)
+	data.CPUUtilPercent, data.MemoryUtilPercent = podUtilization(pod)
+	data.UptimeInSeconds = podUptime(pod)
+	if status := podDesiredStatus(pod); status != "" {
+		data.DesiredStatus = types.StringValue(status)
+	} else if data.DesiredStatus.IsNull() {
+		data.DesiredStatus = types.StringValue("RUNNING")
+	}
+	data.EffectiveEnv = r.effectiveEnv(ctx, data.TemplateID, pod, &resp.Diagnostics)
+	// The API may omit the registry auth, so an empty value keeps the state's
+	if pod.RegistryAuthID != "" {
+		data.ContainerRegistryAuthID = types.StringValue(pod.RegistryAuthID)
+	}
+	data.TotalDiskInGb, data.PersistentDiskInGb = r.podDiskSizes(ctx, &data, pod)
+	resp.Diagnostics.Append(readEnv(ctx, &data, pod)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The following fields are not returned by the API, so preserve state values:
+	// - CloudType: already preserved from state (loaded above)
+	// - SupportPublicIP: already preserved from state (loaded above)
+	// - StartSSH: already preserved from state (loaded above)
+	// - MinVcpuCount: already preserved from state (loaded above)
+	// - MinMemoryInGb: already preserved from state (loaded above)
+	// - NetworkVolumeID: already preserved from state (loaded above)
+	// - TemplateID: already preserved from state (loaded above)
+	// - DataCenterID: already preserved from state (loaded above)
+
+	// Handle cloud_type - set default if not in state
+	if data.CloudType.IsNull() || data.CloudType.IsUnknown() {
+		data.CloudType = types.StringValue("ALL")
+	}
+	if data.InjectDistributedEnv.IsNull() || data.InjectDistributedEnv.IsUnknown() {
+		data.InjectDistributedEnv = types.BoolValue(false)
+	}
+	if data.WaitForRunning.IsNull() || data.WaitForRunning.IsUnknown() {
+		data.WaitForRunning = types.BoolValue(false)
+	}
+	if data.PreventDestroyWithVolume.IsNull() || data.PreventDestroyWithVolume.IsUnknown() {
+		data.PreventDestroyWithVolume = types.BoolValue(false)
+	}
+	if data.PublicIPOptional.IsNull() || data.PublicIPOptional.IsUnknown() {
+		data.PublicIPOptional = types.BoolValue(false)
+	}
+	if data.PublicIPDowngraded.IsNull() || data.PublicIPDowngraded.IsUnknown() {
+		data.PublicIPDowngraded = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PodResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, r.client)
+
+	var plan, state PodResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating pod", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+
+	// RunPod has limited update capabilities - most changes require recreation
+	// For now, we just update the name if possible (though this may not be supported)
+	// Most fields use RequiresReplace so Terraform will recreate the resource
+
+	stopping := plan.DesiredStatus.ValueString() == "STOPPED" && state.DesiredStatus.ValueString() != "STOPPED"
+	resuming := plan.DesiredStatus.ValueString() == "RUNNING" && state.DesiredStatus.ValueString() == "STOPPED"
+
+	if resuming {
+		resp.Diagnostics.Append(r.resumePod(ctx, plan, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// env only reaches Update when every changed key is in mutable_env_keys.
+	// A resumed pod also gets persistent_env applied again.
+	if resuming || !plan.Env.Equal(state.Env) || !plan.PersistentEnv.Equal(state.PersistentEnv) {
+		resp.Diagnostics.Append(r.applyEnv(ctx, state.ID.ValueString(), plan, state)...)
+		if resp.Diagnostics.HasError() {
+			// The edit may have partly applied, so save the env the pod
+			// actually has rather than the plan
+			observed, diags := r.observedEnvState(ctx, plan, state)
+			resp.Diagnostics.Append(diags...)
+			if !diags.HasError() {
+				resp.Diagnostics.Append(resp.State.Set(ctx, &observed)...)
+			}
+			return
+		}
+	}
+
+	if stopping {
+		tflog.Debug(ctx, "Stopping pod", map[string]interface{}{"id": state.ID.ValueString()})
+		if _, err := r.client.StopPod(ctx, state.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Client Error",
+				fmt.Sprintf("Unable to stop pod: %s", err))
+			return
+		}
+	}
+
+	// env edits change the effective env, so read it back
+	pod, err := r.client.GetPod(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to read pod: %s", err))
+		return
+	}
+	plan.EffectiveEnv = r.effectiveEnv(ctx, plan.TemplateID, pod, &resp.Diagnostics)
+
+	// Preserve computed fields
+	plan.ID = state.ID
+	plan.MachineID = state.MachineID
+	plan.PodHostID = state.PodHostID
+	plan.ConsoleURL = state.ConsoleURL
+	plan.ActualGpuTypeID = state.ActualGpuTypeID
+	plan.StatusMessage = state.StatusMessage
+	plan.PortMappings = state.PortMappings
+	plan.RuntimePorts = state.RuntimePorts
+	plan.PublicIP = state.PublicIP
+	plan.SSHHost = state.SSHHost
+	plan.SSHPort = state.SSHPort
+	plan.SSHCommand = state.SSHCommand
+	plan.ActualCloudType = state.ActualCloudType
+	plan.Location = state.Location
+	plan.StableName = plan.Name
+	plan.TotalDiskInGb = state.TotalDiskInGb
+	plan.PersistentDiskInGb = state.PersistentDiskInGb
+	plan.ActualGpuCount = state.ActualGpuCount
+	plan.InlineNetworkVolumeID = state.InlineNetworkVolumeID
+	plan.CPUUtilPercent = state.CPUUtilPercent
+	plan.MemoryUtilPercent = state.MemoryUtilPercent
+	plan.UptimeInSeconds = state.UptimeInSeconds
+	plan.DeployedImageName = state.DeployedImageName
+	plan.PublicIPDowngraded = state.PublicIPDowngraded
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// resumePod resumes a stopped pod with the number of GPUs it was deployed
+// with and, with wait_for_running, waits for it to become ready again
+func (r *PodResource) resumePod(ctx context.Context, plan, state PodResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	id := state.ID.ValueString()
+
+	gpuCount := state.ActualGpuCount
+	if gpuCount.IsNull() || gpuCount.IsUnknown() {
+		gpuCount = state.GpuCount
+	}
+
+	tflog.Debug(ctx, "Resuming pod", map[string]interface{}{"id": id, "gpu_count": gpuCount.ValueInt64()})
+
+	var err error
+	if state.Interruptible.ValueBool() {
+		_, err = r.client.ResumeInterruptiblePod(ctx, id, int(gpuCount.ValueInt64()), state.BidPerGpu.ValueFloat64())
+	} else {
+		_, err = r.client.ResumePod(ctx, id, int(gpuCount.ValueInt64()))
+	}
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to resume pod: %s", err))
+		return diags
+	}
+
+	timeout, timeoutDiags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	diags.Append(timeoutDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if plan.WaitForRunning.ValueBool() && timeout > 0 {
+		if _, err := waitForPodRunning(ctx, r.client, id, int(plan.MinUptimeSeconds.ValueInt64()), timeout); err != nil {
+			diags.AddError("Pod Not Ready", fmt.Sprintf("Pod %s was resumed but did not become ready: %s", id, err))
+		}
+	}
+	return diags
+}
+
+// applyEnv edits the pod so its environment contains the planned env and
+// persistent_env, removing keys that are no longer configured.
+func (r *PodResource) applyEnv(ctx context.Context, id string, plan, state PodResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	oldEnv := make(map[string]string)
+	env := make(map[string]string)
+	oldPersistent := make(map[string]string)
+	newPersistent := make(map[string]string)
+	if !state.Env.IsNull() {
+		diags.Append(state.Env.ElementsAs(ctx, &oldEnv, false)...)
+	}
+	if !plan.Env.IsNull() {
+		diags.Append(plan.Env.ElementsAs(ctx, &env, false)...)
+	}
+	if !state.PersistentEnv.IsNull() {
+		diags.Append(state.PersistentEnv.ElementsAs(ctx, &oldPersistent, false)...)
+	}
+	if !plan.PersistentEnv.IsNull() {
+		diags.Append(plan.PersistentEnv.ElementsAs(ctx, &newPersistent, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	tflog.Debug(ctx, "Applying env", map[string]interface{}{"id": id})
+
+	_, err := editPodWithRetry(ctx, r.client, id, func(pod *Pod) *PodEditInput {
+		current := make(map[string]string, len(pod.Env))
+		for _, e := range pod.Env {
+			current[e.Key] = e.Value
+		}
+
+		merged := mergeEnvChanges(current, oldEnv, env, newPersistent)
+		merged = mergePersistentEnv(merged, env, oldPersistent, newPersistent)
+		if reflect.DeepEqual(merged, current) {
+			return nil
+		}
+
+		input := NewPodEditInput(pod)
+		input.Env = input.Env[:0]
+		for k, v := range merged {
+			input.Env = append(input.Env, EnvVar{Key: k, Value: v})
+		}
+		return input
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to apply env: %s", err))
+	}
+
+	return diags
+}
+
+// editPodWithRetry performs a read-modify-write edit of a pod. The current pod
+// is passed to modify, which returns the edit to apply or nil if none is needed.
+// When the edit conflicts with a concurrent change, the pod is re-read and the
+// edit recomputed, up to maxEditAttempts times.
+func editPodWithRetry(ctx context.Context, client *Client, id string, modify func(*Pod) *PodEditInput) (*Pod, error) {
+	for attempt := 1; ; attempt++ {
+		pod, err := client.GetPod(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		input := modify(pod)
+		if input == nil {
+			return pod, nil
+		}
+
+		edited, err := client.EditPod(ctx, input)
+		if err == nil {
+			return edited, nil
+		}
+		if !isConflictError(err) || attempt >= maxEditAttempts {
+			return nil, err
 		}
+
+		tflog.Warn(ctx, "Pod edit conflicted with a concurrent change, retrying", map[string]interface{}{
+			"id":      id,
+			"attempt": attempt,
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(editConflictRetryDelay):
+		}
+	}
+}
+
+func (r *PodResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, r.client)
+
+	var data PodResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(volumeDestroyGuard(&data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Terminating pod", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+
+	err := r.client.TerminatePodBatched(ctx, data.ID.ValueString())
+	// Ignore "not found" errors during delete
+	if err != nil && !IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error",
 			fmt.Sprintf("Unable to terminate pod: %s", err))
 		return
@@ -464,8 +2019,992 @@ func (r *PodResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	tflog.Trace(ctx, "Terminated pod", map[string]interface{}{
 		"id": data.ID.ValueString(),
 	})
+
+	if id := data.InlineNetworkVolumeID.ValueString(); id != "" {
+		resp.Diagnostics.Append(r.deleteInlineVolume(ctx, id)...)
+	}
+}
+
+// deleteInlineVolume deletes a network volume created from
+// inline_network_volume. The volume stays attached for a short time after its
+// pod is terminated, so deletion is retried. A volume already deleted is not
+// an error.
+func (r *PodResource) deleteInlineVolume(ctx context.Context, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for attempt := 1; ; attempt++ {
+		err := r.client.DeleteNetworkVolume(ctx, id)
+		if err == nil || IsNotFound(err) {
+			return diags
+		}
+		if attempt >= maxVolumeReadyAttempts {
+			diags.AddError("Client Error",
+				fmt.Sprintf("Unable to delete inline network volume %s, delete it manually: %s", id, err))
+			return diags
+		}
+
+		tflog.Warn(ctx, "Unable to delete inline network volume, retrying", map[string]interface{}{
+			"network_volume_id": id,
+			"attempt":           attempt,
+			"error":             err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			diags.AddError("Client Error",
+				fmt.Sprintf("Unable to delete inline network volume %s, delete it manually: %s", id, ctx.Err()))
+			return diags
+		case <-time.After(volumeReadyRetryDelay):
+		}
+	}
 }
 
+// podImportNamePrefix marks an import ID that is a pod name rather than a pod ID
+const podImportNamePrefix = "name:"
+
 func (r *PodResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	name, byName := strings.CutPrefix(req.ID, podImportNamePrefix)
+	if !byName {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	id, err := r.podIDByName(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Import Pod", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// podIDByName returns the ID of the only pod in the account named name
+func (r *PodResource) podIDByName(ctx context.Context, name string) (string, error) {
+	pods, err := r.client.ListPods(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to list pods: %w", err)
+	}
+
+	var ids []string
+	for _, pod := range pods {
+		if pod.Name == name {
+			ids = append(ids, pod.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no pod is named %q", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d pods are named %q (%s); import one of them by ID instead",
+			len(ids), name, strings.Join(ids, ", "))
+	}
+}
+
+// distributedEnv returns the environment variables commonly expected by
+// multi-GPU frameworks (torchrun, NCCL) for a single-node pod with gpuCount GPUs.
+func distributedEnv(gpuCount int) map[string]string {
+	devices := make([]string, gpuCount)
+	for i := range devices {
+		devices[i] = strconv.Itoa(i)
+	}
+
+	return map[string]string{
+		"WORLD_SIZE":           strconv.Itoa(gpuCount),
+		"LOCAL_WORLD_SIZE":     strconv.Itoa(gpuCount),
+		"CUDA_VISIBLE_DEVICES": strings.Join(devices, ","),
+	}
+}
+
+// waitForPodRunning polls the pod until it reports RUNNING with a populated
+// runtime and, when minUptime is positive, at least minUptime seconds of uptime.
+func waitForPodRunning(ctx context.Context, client *Client, id string, minUptime int, timeout time.Duration) (*Pod, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		pod, err := client.GetPod(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if podIsReady(pod, minUptime) {
+			return pod, nil
+		}
+
+		if time.Now().After(deadline) {
+			uptime := 0
+			if pod.Runtime != nil {
+				uptime = pod.Runtime.UptimeInSeconds
+			}
+			return pod, fmt.Errorf("timed out after %s (last status: %s, uptime: %ds)", timeout, pod.DesiredStatus, uptime)
+		}
+
+		select {
+		case <-ctx.Done():
+			return pod, ctx.Err()
+		case <-time.After(podPollInterval):
+		}
+	}
+}
+
+// podIsReady reports whether the pod is running and has been up for at least minUptime seconds
+func podIsReady(pod *Pod, minUptime int) bool {
+	if pod.DesiredStatus != "RUNNING" || pod.Runtime == nil {
+		return false
+	}
+	return pod.Runtime.UptimeInSeconds >= minUptime
+}
+
+// observedEnvState returns state with env and persistent_env replaced by the
+// values the pod currently has for the planned and prior keys, so the next
+// plan shows what is left to apply after a failed edit.
+func (r *PodResource) observedEnvState(ctx context.Context, plan, state PodResourceModel) (PodResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	pod, err := r.client.GetPod(ctx, state.ID.ValueString())
+	if err != nil {
+		diags.AddError("Client Error",
+			fmt.Sprintf("Unable to read pod after a failed env edit, state may not match the pod: %s", err))
+		return state, diags
+	}
+
+	current := make(map[string]string, len(pod.Env))
+	for _, e := range pod.Env {
+		current[e.Key] = e.Value
+	}
+
+	state.Env, diags = observedEnv(ctx, current, state.Env, plan.Env)
+	if diags.HasError() {
+		return state, diags
+	}
+	persistent, persistentDiags := observedEnv(ctx, current, state.PersistentEnv, plan.PersistentEnv)
+	diags.Append(persistentDiags...)
+	state.PersistentEnv = persistent
+
+	return state, diags
+}
+
+// observedEnv returns the values current has for the keys of prior and
+// planned. It is null when neither is set.
+func observedEnv(ctx context.Context, current map[string]string, prior, planned types.Map) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if prior.IsNull() && planned.IsNull() {
+		return prior, diags
+	}
+
+	keys := make(map[string]bool)
+	for _, m := range []types.Map{prior, planned} {
+		if m.IsNull() || m.IsUnknown() {
+			continue
+		}
+		var env map[string]string
+		diags.Append(m.ElementsAs(ctx, &env, false)...)
+		for k := range env {
+			keys[k] = true
+		}
+	}
+	if diags.HasError() {
+		return prior, diags
+	}
+
+	observed := make(map[string]string, len(keys))
+	for k := range keys {
+		if v, ok := current[k]; ok {
+			observed[k] = v
+		}
+	}
+
+	value, valueDiags := types.MapValueFrom(ctx, types.StringType, observed)
+	diags.Append(valueDiags...)
+	return value, diags
+}
+
+// readEnv updates env and persistent_env with the values the pod has for the
+// keys already in state, so changes made outside Terraform show up as drift.
+// Keys RunPod or the provider add, such as RUNPOD_POD_ID, are not in state and
+// so are ignored. An env key also set by persistent_env keeps its state value,
+// since the persistent value is the one deployed, as does a key matching
+// ignore_env_keys.
+func readEnv(ctx context.Context, data *PodResourceModel, pod *Pod) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// The API returned no env, so keep the state values
+	if pod.Env == nil {
+		return diags
+	}
+
+	ignored, ignoreDiags := envKeyMatcher(ctx, data.IgnoreEnvKeys)
+	diags.Append(ignoreDiags...)
+
+	var stateEnv, statePersistent map[string]string
+	if !data.Env.IsNull() {
+		diags.Append(data.Env.ElementsAs(ctx, &stateEnv, false)...)
+	}
+	if !data.PersistentEnv.IsNull() {
+		diags.Append(data.PersistentEnv.ElementsAs(ctx, &statePersistent, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	current := make(map[string]string, len(pod.Env))
+	for _, e := range pod.Env {
+		current[e.Key] = e.Value
+	}
+
+	persistent, persistentDiags := observedEnv(ctx, keepIgnoredEnv(current, statePersistent, ignored),
+		data.PersistentEnv, types.MapNull(types.StringType))
+	diags.Append(persistentDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	forEnv := keepIgnoredEnv(current, stateEnv, ignored)
+	for k := range statePersistent {
+		if v, ok := stateEnv[k]; ok {
+			forEnv[k] = v
+		}
+	}
+
+	env, envDiags := observedEnv(ctx, forEnv, data.Env, types.MapNull(types.StringType))
+	diags.Append(envDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.Env = env
+	data.PersistentEnv = persistent
+	return diags
+}
+
+// envKeyMatcher returns a function reporting whether a key matches one of the
+// ignore_env_keys patterns. Each pattern must match the whole key.
+func envKeyMatcher(ctx context.Context, patterns types.List) (func(string) bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var values []string
+	if !patterns.IsNull() && !patterns.IsUnknown() {
+		diags.Append(patterns.ElementsAs(ctx, &values, false)...)
+	}
+
+	var compiled []*regexp.Regexp
+	for i, pattern := range values {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			diags.AddAttributeError(path.Root("ignore_env_keys").AtListIndex(i), "Invalid Configuration",
+				fmt.Sprintf("%q is not a valid key or regular expression: %s", pattern, err))
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+
+	return func(key string) bool {
+		for _, re := range compiled {
+			if re.MatchString(key) {
+				return true
+			}
+		}
+		return false
+	}, diags
+}
+
+// keepIgnoredEnv returns a copy of current in which the keys of prior that
+// match ignored keep their prior values
+func keepIgnoredEnv(current, prior map[string]string, ignored func(string) bool) map[string]string {
+	kept := make(map[string]string, len(current))
+	for k, v := range current {
+		kept[k] = v
+	}
+	for k, v := range prior {
+		if ignored(k) {
+			kept[k] = v
+		}
+	}
+	return kept
+}
+
+// mergeEnvChanges returns the pod environment with the keys that differ
+// between oldEnv and newEnv applied. Removed keys are deleted unless
+// persistent_env still sets them.
+func mergeEnvChanges(current, oldEnv, newEnv, persistent map[string]string) map[string]string {
+	merged := make(map[string]string, len(current))
+	for k, v := range current {
+		merged[k] = v
+	}
+
+	for _, k := range changedEnvKeys(oldEnv, newEnv) {
+		if v, ok := newEnv[k]; ok {
+			merged[k] = v
+		} else if _, ok := persistent[k]; !ok {
+			delete(merged, k)
+		}
+	}
+
+	return merged
+}
+
+// changedEnvKeys returns the keys added, removed or changed between oldEnv
+// and newEnv
+func changedEnvKeys(oldEnv, newEnv map[string]string) []string {
+	var changed []string
+	for k, v := range newEnv {
+		if old, ok := oldEnv[k]; !ok || old != v {
+			changed = append(changed, k)
+		}
+	}
+	for k := range oldEnv {
+		if _, ok := newEnv[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	return changed
+}
+
+// envRequiresReplace is a plan modifier that replaces the pod when env
+// changes a key that is not listed in mutable_env_keys
+type envRequiresReplace struct{}
+
+func (m envRequiresReplace) Description(ctx context.Context) string {
+	return "Replaces the pod when an env key not listed in mutable_env_keys changes."
+}
+
+func (m envRequiresReplace) MarkdownDescription(ctx context.Context) string {
+	return "Replaces the pod when an env key not listed in `mutable_env_keys` changes."
+}
+
+func (m envRequiresReplace) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	// Nothing to replace on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	if req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+	if req.PlanValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	oldEnv := make(map[string]string)
+	newEnv := make(map[string]string)
+	if !req.StateValue.IsNull() {
+		resp.Diagnostics.Append(req.StateValue.ElementsAs(ctx, &oldEnv, false)...)
+	}
+	if !req.PlanValue.IsNull() {
+		resp.Diagnostics.Append(req.PlanValue.ElementsAs(ctx, &newEnv, false)...)
+	}
+
+	var mutableKeys types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("mutable_env_keys"), &mutableKeys)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if mutableKeys.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	var keys []string
+	if !mutableKeys.IsNull() {
+		resp.Diagnostics.Append(mutableKeys.ElementsAs(ctx, &keys, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.RequiresReplace = !onlyMutableEnvChanged(oldEnv, newEnv, keys)
+}
+
+// onlyMutableEnvChanged reports whether every key that differs between oldEnv
+// and newEnv is one of mutableKeys
+func onlyMutableEnvChanged(oldEnv, newEnv map[string]string, mutableKeys []string) bool {
+	mutable := make(map[string]bool, len(mutableKeys))
+	for _, k := range mutableKeys {
+		mutable[k] = true
+	}
+
+	for _, k := range changedEnvKeys(oldEnv, newEnv) {
+		if !mutable[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// mergePersistentEnv returns the pod environment with newPersistent applied.
+// Keys only present in oldPersistent are removed, unless env still sets them.
+func mergePersistentEnv(current, env, oldPersistent, newPersistent map[string]string) map[string]string {
+	merged := make(map[string]string, len(current))
+	for k, v := range current {
+		merged[k] = v
+	}
+
+	for k := range oldPersistent {
+		if _, ok := newPersistent[k]; ok {
+			continue
+		}
+		if v, ok := env[k]; ok {
+			merged[k] = v
+		} else {
+			delete(merged, k)
+		}
+	}
+
+	for k, v := range newPersistent {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// podDesiredStatus returns the desired_status matching the pod's status, or
+// an empty string for statuses desired_status cannot express
+func podDesiredStatus(pod *Pod) string {
+	switch pod.DesiredStatus {
+	case "RUNNING":
+		return "RUNNING"
+	case "EXITED":
+		return "STOPPED"
+	}
+	return ""
+}
+
+// podConsoleURL returns the RunPod web console URL for a pod
+func podConsoleURL(id string) string {
+	return consoleURLPrefix + id
+}
+
+// requestedGpuTypeIDs returns the pod's GPU types in order of preference:
+// gpu_type_id, then gpu_type_ids, without duplicates
+func requestedGpuTypeIDs(ctx context.Context, data *PodResourceModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	input := PodInput{GpuTypeID: data.GpuTypeID.ValueString()}
+	if !data.GpuTypeIDs.IsNull() && !data.GpuTypeIDs.IsUnknown() {
+		diags.Append(data.GpuTypeIDs.ElementsAs(ctx, &input.GpuTypeIDs, false)...)
+	}
+	ids := input.gpuTypeIDs()
+	if len(ids) == 0 && !diags.HasError() {
+		diags.AddAttributeError(path.Root("gpu_type_id"), "Invalid Configuration",
+			"At least one of gpu_type_id and gpu_type_ids must name a GPU type.")
+	}
+	return ids, diags
+}
+
+// podGpuTypeID returns the GPU type the pod is deployed on. The pod's own
+// gpuTypeId is preferred since machine is null while a pod is stopped.
+func podGpuTypeID(pod *Pod) string {
+	if pod.GpuTypeID != "" {
+		return pod.GpuTypeID
+	}
+	if pod.Machine != nil {
+		return pod.Machine.GpuTypeID
+	}
+	return ""
+}
+
+// gpuTypeMismatchDiagnostics warns when the pod was deployed on a GPU type
+// other than the requested ones
+func gpuTypeMismatchDiagnostics(requested []string, actual string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(requested) == 0 || actual == "" || slices.Contains(requested, actual) {
+		return diags
+	}
+	if len(requested) == 1 {
+		diags.AddWarning("GPU Type Differs From Request",
+			fmt.Sprintf("The pod requested GPU type %q but is running on %q. See actual_gpu_type_id for the deployed type.", requested[0], actual))
+	} else {
+		diags.AddWarning("GPU Type Differs From Request",
+			fmt.Sprintf("The pod requested one of the GPU types %q but is running on %q. See actual_gpu_type_id for the deployed type.", requested, actual))
+	}
+	return diags
+}
+
+// diskRoundingDiagnostics warns when RunPod allocated different disk sizes
+// than requested, e.g. after rounding to an allowed increment. Read stores the
+// allocated sizes, so without the warning the next plan would show an
+// unexplained diff.
+func diskRoundingDiagnostics(input *PodInput, pod *Pod) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, disk := range []struct {
+		attribute          string
+		requested, applied int
+	}{
+		{"container_disk_in_gb", input.ContainerDiskInGb, pod.ContainerDiskInGb},
+		{"volume_in_gb", input.VolumeInGb, pod.VolumeInGb},
+	} {
+		if disk.applied == 0 || disk.applied == disk.requested {
+			continue
+		}
+		diags.AddAttributeWarning(path.Root(disk.attribute), "Disk Size Adjusted",
+			fmt.Sprintf("Pod %s requested %d GB for %s but RunPod allocated %d GB, which is what is billed. "+
+				"Set %s = %d to match the allocated size and avoid a diff on the next plan.",
+				pod.ID, disk.requested, disk.attribute, disk.applied, disk.attribute, disk.applied))
+	}
+	return diags
+}
+
+// imageOverrideDiagnostics explains a pod running a different image than
+// image_name, which happens when its template's image takes precedence
+func imageOverrideDiagnostics(requested, actual, templateID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if requested == "" || actual == "" || imagesEquivalent(requested, actual) {
+		return diags
+	}
+
+	detail := fmt.Sprintf("The pod is running image %q instead of image_name %q. See deployed_image_name for the running image.", actual, requested)
+	if templateID != "" {
+		detail = fmt.Sprintf("The image of template %s overrode image_name %q, so the pod is running %q. "+
+			"See deployed_image_name for the running image.", templateID, requested, actual)
+	}
+	diags.AddAttributeWarning(path.Root("image_name"), "Deployed Image Differs From image_name", detail)
+	return diags
+}
+
+// optionalString converts an API string to a Terraform value, using null when it is empty
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// runtimeEnvPlaceholder matches the placeholders supported in runtime_env templates
+var runtimeEnvPlaceholder = regexp.MustCompile(`\{\{\s*(pod_id|public_ip|public_port:(\d+))\s*\}\}`)
+
+// renderRuntimeEnv resolves the placeholders in a runtime_env template using
+// the pod's runtime ports
+func renderRuntimeEnv(tmpl string, pod *Pod) (string, error) {
+	var renderErr error
+	rendered := runtimeEnvPlaceholder.ReplaceAllStringFunc(tmpl, func(match string) string {
+		groups := runtimeEnvPlaceholder.FindStringSubmatch(match)
+		switch {
+		case groups[1] == "pod_id":
+			return pod.ID
+		case groups[1] == "public_ip":
+			if port := publicPort(pod, 0); port != nil {
+				return port.IP
+			}
+			renderErr = fmt.Errorf("pod has no public IP")
+		default:
+			privatePort, _ := strconv.Atoi(groups[2])
+			if port := publicPort(pod, privatePort); port != nil {
+				return strconv.Itoa(port.PublicPort)
+			}
+			renderErr = fmt.Errorf("pod has no public mapping for port %d", privatePort)
+		}
+		return match
+	})
+	return rendered, renderErr
+}
+
+// publicPort returns the public mapping of privatePort, or of any port when
+// privatePort is zero. It returns nil when the pod has no such mapping.
+func publicPort(pod *Pod, privatePort int) *Port {
+	if pod.Runtime == nil {
+		return nil
+	}
+	for i, port := range pod.Runtime.Ports {
+		if port.IsIPPublic && (privatePort == 0 || port.PrivatePort == privatePort) {
+			return &pod.Runtime.Ports[i]
+		}
+	}
+	return nil
+}
+
+// volumeDestroyGuard returns an error when prevent_destroy_with_volume is set
+// and the pod has an inline volume. The API does not report whether the volume
+// holds data, so any inline volume is treated as non-empty.
+func volumeDestroyGuard(data *PodResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !data.PreventDestroyWithVolume.ValueBool() || data.VolumeInGb.ValueInt64() <= 0 {
+		return diags
+	}
+
+	diags.AddError("Pod Destroy Prevented",
+		fmt.Sprintf("Pod %s has a %d GB inline volume that would be deleted with it, and prevent_destroy_with_volume is set. "+
+			"Set prevent_destroy_with_volume = false and apply before destroying or replacing the pod.",
+			data.ID.ValueString(), data.VolumeInGb.ValueInt64()))
+	return diags
+}
+
+// cudaVersionDetail explains how to recover when no machine's driver
+// supports the pod's CUDA requirement
+func cudaVersionDetail(allowed []string, msg string) string {
+	return fmt.Sprintf("The host driver does not support the required CUDA version: %s\n\n"+
+		"allowed_cuda_versions is %v. Allow newer versions (RunPod supports %s) or remove allowed_cuda_versions "+
+		"and pick an image built for an older CUDA.", msg, allowed, strings.Join(knownCUDAVersions, ", "))
+}
+
+// knownCUDAVersion is a validator that warns about CUDA versions RunPod is
+// not known to offer. It does not fail, as newer versions are added over time.
+type knownCUDAVersion struct{}
+
+func (v knownCUDAVersion) Description(ctx context.Context) string {
+	return "value should be a CUDA version offered by RunPod"
+}
+
+func (v knownCUDAVersion) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v knownCUDAVersion) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, known := range knownCUDAVersions {
+		if req.ConfigValue.ValueString() == known {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeWarning(req.Path, "Unknown CUDA Version",
+		fmt.Sprintf("CUDA version %q is not one RunPod is known to offer (%s), so no machine may match it.",
+			req.ConfigValue.ValueString(), strings.Join(knownCUDAVersions, ", ")))
+}
+
+// portsFormat is a validator that checks each comma-separated entry of a
+// ports string is PORT/PROTOCOL, with a port from 1 to 65535 and a protocol of
+// http or tcp
+type portsFormat struct{}
+
+func (v portsFormat) Description(ctx context.Context) string {
+	return "value must be comma-separated PORT/PROTOCOL entries, e.g. 8888/http,22/tcp"
+}
+
+func (v portsFormat) MarkdownDescription(ctx context.Context) string {
+	return "value must be comma-separated `PORT/PROTOCOL` entries, e.g. `8888/http,22/tcp`"
+}
+
+func (v portsFormat) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, spec := range strings.Split(req.ConfigValue.ValueString(), ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		if err := checkPortSpec(spec); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Port",
+				fmt.Sprintf("%q is not a valid port: %s. Ports must be comma-separated PORT/PROTOCOL entries, e.g. \"8888/http,22/tcp\".", spec, err))
+		}
+	}
+}
+
+// checkPortSpec checks a single PORT/PROTOCOL entry of a ports string
+func checkPortSpec(spec string) error {
+	port, protocol, ok := strings.Cut(spec, "/")
+	if !ok {
+		return fmt.Errorf("missing /PROTOCOL")
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("port %q must be a number from 1 to 65535", port)
+	}
+	if p := strings.ToLower(protocol); p != "http" && p != "tcp" {
+		return fmt.Errorf("protocol %q must be http or tcp", protocol)
+	}
+	return nil
+}
+
+// registryAuthFailureDetail explains how to recover when the registry rejects
+// a pod's credentials
+func registryAuthFailureDetail(authID, msg string) string {
+	return fmt.Sprintf("The registry rejected credential %s while pulling the image: %s\n\n"+
+		"Cloud registry tokens such as ECR and GCR access tokens expire after a few hours. Refresh the token "+
+		"(e.g. with aws ecr get-login-password or gcloud auth print-access-token), update the credential in "+
+		"RunPod and apply again.", authID, msg)
+}
+
+// imagesEquivalent reports whether two image references name the same image,
+// ignoring an implicit :latest tag and Docker Hub's default registry
+func imagesEquivalent(a, b string) bool {
+	return normalizeImageName(a) == normalizeImageName(b)
+}
+
+// normalizeImageName returns a canonical form of an image reference such as
+// "runpod/base": Docker Hub's docker.io/ and library/ prefixes are dropped and
+// an untagged image gets its implicit :latest tag
+func normalizeImageName(image string) string {
+	image = strings.TrimSpace(image)
+	image = strings.TrimPrefix(image, "docker.io/")
+	image = strings.TrimPrefix(image, "library/")
+
+	name := image[strings.LastIndex(image, "/")+1:]
+	if !strings.ContainsAny(name, ":@") {
+		image += ":latest"
+	}
+	return image
+}
+
+// portsEquivalent reports whether two ports strings expose the same ports,
+// ignoring order, whitespace and the case of the protocol
+func portsEquivalent(a, b string) bool {
+	return normalizePorts(a) == normalizePorts(b)
+}
+
+// normalizePorts returns a canonical form of a ports string such as
+// "8888/http, 22/tcp"
+func normalizePorts(ports string) string {
+	specs := parsePorts(ports)
+	sort.Strings(specs)
+	return strings.Join(specs, ",")
+}
+
+// parsePorts splits a ports string into its lowercased port specs, skipping
+// empty entries
+func parsePorts(ports string) []string {
+	var specs []string
+	for _, spec := range strings.Split(ports, ",") {
+		spec = strings.ToLower(strings.TrimSpace(spec))
+		if spec != "" {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+// portsSpec returns the ports string to deploy with, from ports or
+// exposed_ports, and whether it is known
+func portsSpec(ctx context.Context, ports types.String, exposedPorts types.List) (string, bool) {
+	if ports.IsUnknown() || exposedPorts.IsUnknown() {
+		return "", false
+	}
+	if exposedPorts.IsNull() {
+		return ports.ValueString(), true
+	}
+
+	var entries []ExposedPortModel
+	if diags := exposedPorts.ElementsAs(ctx, &entries, false); diags.HasError() {
+		return "", false
+	}
+	specs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.Port.IsUnknown() || e.Protocol.IsUnknown() {
+			return "", false
+		}
+		specs = append(specs, fmt.Sprintf("%d/%s", e.Port.ValueInt64(), e.Protocol.ValueString()))
+	}
+	return strings.Join(specs, ","), true
+}
+
+// portsAttribute returns the path of the attribute the pod's ports are
+// configured with, exposed_ports when it is set and ports otherwise
+func portsAttribute(exposedPorts types.List) path.Path {
+	if exposedPorts.IsNull() {
+		return path.Root("ports")
+	}
+	return path.Root("exposed_ports")
+}
+
+// exposedPortsValue converts a ports string from the API to an exposed_ports
+// list, skipping entries that are not PORT/PROTOCOL
+func exposedPortsValue(ports string) types.List {
+	elemType := types.ObjectType{AttrTypes: exposedPortAttrTypes}
+	entries := []attr.Value{}
+	for _, spec := range parsePorts(ports) {
+		port, protocol, _ := strings.Cut(spec, "/")
+		n, err := strconv.Atoi(port)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, types.ObjectValueMust(exposedPortAttrTypes, map[string]attr.Value{
+			"port":     types.Int64Value(int64(n)),
+			"protocol": types.StringValue(protocol),
+		}))
+	}
+	return types.ListValueMust(elemType, entries)
+}
+
+// maxExposedPorts is the most ports RunPod exposes on a single pod
+const maxExposedPorts = 10
+
+// portCountDiagnostics reports a ports string, configured at attribute,
+// exposing more than maxExposedPorts ports, as an error when strict and a
+// warning otherwise
+func portCountDiagnostics(attribute path.Path, ports string, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	count := len(parsePorts(ports))
+	if count <= maxExposedPorts {
+		return diags
+	}
+
+	summary := "Too Many Ports"
+	detail := fmt.Sprintf("%s exposes %d ports, but RunPod exposes at most %d per pod, so the deploy is likely to fail.",
+		attribute, count, maxExposedPorts)
+	if strict {
+		diags.AddAttributeError(attribute, summary, detail)
+	} else {
+		diags.AddAttributeWarning(attribute, summary, detail)
+	}
+	return diags
+}
+
+// effectiveEnv returns the pod's env merged over the env of its template. If
+// the template cannot be read, a warning is added and only the pod's env is
+// returned.
+func (r *PodResource) effectiveEnv(ctx context.Context, templateID types.String, pod *Pod, diags *diag.Diagnostics) types.Map {
+	var templateEnv EnvVars
+	if id := templateID.ValueString(); id != "" {
+		template, err := r.client.GetTemplate(ctx, id)
+		if err != nil {
+			diags.AddAttributeWarning(path.Root("effective_env"), "Template Env Unavailable",
+				fmt.Sprintf("Unable to read template %s, so effective_env only contains the pod's env: %s", id, err))
+		} else {
+			templateEnv = template.Env
+		}
+	}
+
+	env := make(map[string]attr.Value, len(templateEnv)+len(pod.Env))
+	for _, e := range templateEnv {
+		env[e.Key] = types.StringValue(e.Value)
+	}
+	for _, e := range pod.Env {
+		env[e.Key] = types.StringValue(e.Value)
+	}
+	return types.MapValueMust(types.StringType, env)
+}
+
+// podCloudType returns the cloud the pod's machine belongs to, or an empty
+// string when the pod has no machine
+func podCloudType(pod *Pod) string {
+	if pod.Machine == nil {
+		return ""
+	}
+	if pod.Machine.SecureCloud {
+		return "SECURE"
+	}
+	return "COMMUNITY"
+}
+
+// podDiskSizes returns the pod's total and persistent storage. The persistent
+// storage is the attached network volume's size, or the pod's volume without
+// one. The size comes from the pod's networkVolume field, and is only looked
+// up by ID when the pod was returned without it, such as by the deploy
+// mutations. Both are null if the network volume cannot be read.
+func (r *PodResource) podDiskSizes(ctx context.Context, data *PodResourceModel, pod *Pod) (types.Int64, types.Int64) {
+	volumeID := pod.NetworkVolumeID
+	if volumeID == "" {
+		volumeID = data.NetworkVolumeID.ValueString()
+	}
+	if volumeID == "" {
+		volumeID = data.InlineNetworkVolumeID.ValueString()
+	}
+
+	persistent := pod.VolumeInGb
+	if pod.NetworkVolume != nil && pod.NetworkVolume.ID == volumeID {
+		persistent = pod.NetworkVolume.Size
+	} else if volumeID != "" {
+		volume, err := r.client.FindNetworkVolume(ctx, volumeID)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to read network volume size", map[string]interface{}{
+				"network_volume_id": volumeID,
+				"error":             err.Error(),
+			})
+			return types.Int64Null(), types.Int64Null()
+		}
+		persistent = volume.Size
+	}
+
+	return types.Int64Value(int64(pod.ContainerDiskInGb + persistent)), types.Int64Value(int64(persistent))
+}
+
+// podLocation returns the location of the pod's machine, or an empty string
+// when the pod has no machine
+func podLocation(pod *Pod) string {
+	if pod.Machine == nil {
+		return ""
+	}
+	return pod.Machine.Location
+}
+
+// podUtilization returns the container's CPU and memory utilization, or nulls
+// when the pod has no runtime
+func podUtilization(pod *Pod) (types.Int64, types.Int64) {
+	if pod.Runtime == nil || pod.Runtime.Container == nil {
+		return types.Int64Null(), types.Int64Null()
+	}
+	container := pod.Runtime.Container
+	return types.Int64Value(int64(container.CPUPercent)), types.Int64Value(int64(container.MemoryPercent))
+}
+
+// podUptime returns the container's uptime in seconds, or null when the pod
+// has no runtime
+func podUptime(pod *Pod) types.Int64 {
+	if pod.Runtime == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(pod.Runtime.UptimeInSeconds))
+}
+
+// podPortMappings returns the pod's public port for each private port. The map
+// is empty while the pod is provisioning and has no runtime yet.
+func podPortMappings(pod *Pod) types.Map {
+	mappings := make(map[string]attr.Value)
+	if pod.Runtime != nil {
+		for _, port := range pod.Runtime.Ports {
+			if port.IsIPPublic {
+				mappings[strconv.Itoa(port.PrivatePort)] = types.Int64Value(int64(port.PublicPort))
+			}
+		}
+	}
+	return types.MapValueMust(types.Int64Type, mappings)
+}
+
+// runtimePortAttrTypes are the attribute types of a runtime_ports element
+var runtimePortAttrTypes = map[string]attr.Type{
+	"ip":           types.StringType,
+	"public_port":  types.Int64Type,
+	"private_port": types.Int64Type,
+	"type":         types.StringType,
+	"is_public":    types.BoolType,
+}
+
+// podRuntimePorts returns every port of the running pod. The list is empty
+// while the pod is provisioning and has no runtime yet.
+func podRuntimePorts(pod *Pod) types.List {
+	elemType := types.ObjectType{AttrTypes: runtimePortAttrTypes}
+	ports := []attr.Value{}
+	if pod.Runtime != nil {
+		for _, port := range pod.Runtime.Ports {
+			ports = append(ports, types.ObjectValueMust(runtimePortAttrTypes, map[string]attr.Value{
+				"ip":           types.StringValue(port.IP),
+				"public_port":  types.Int64Value(int64(port.PublicPort)),
+				"private_port": types.Int64Value(int64(port.PrivatePort)),
+				"type":         types.StringValue(port.Type),
+				"is_public":    types.BoolValue(port.IsIPPublic),
+			}))
+		}
+	}
+	return types.ListValueMust(elemType, ports)
+}
+
+// podPublicIP returns the IP of the pod's public ports, or null when none is public
+func podPublicIP(pod *Pod) types.String {
+	port := publicPort(pod, 0)
+	if port == nil {
+		return types.StringNull()
+	}
+	return optionalString(port.IP)
+}
+
+// podSSH returns the public host, port and command to reach the pod's SSH
+// server on private port 22, or nulls while the pod has no public TCP mapping
+// for it
+func podSSH(pod *Pod) (types.String, types.Int64, types.String) {
+	port := publicPort(pod, 22)
+	if port == nil || port.IP == "" || port.Type == "http" {
+		return types.StringNull(), types.Int64Null(), types.StringNull()
+	}
+	return types.StringValue(port.IP), types.Int64Value(int64(port.PublicPort)),
+		types.StringValue(fmt.Sprintf("ssh root@%s -p %d", port.IP, port.PublicPort))
+}
+
+// setEnvVar sets key to value in env, replacing any existing entry
+func setEnvVar(env []EnvVar, key, value string) []EnvVar {
+	for i := range env {
+		if env[i].Key == key {
+			env[i].Value = value
+			return env
+		}
+	}
+	return append(env, EnvVar{Key: key, Value: value})
 }
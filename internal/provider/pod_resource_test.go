@@ -1,9 +1,27 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -19,15 +37,16 @@ func TestAccPodResource_lifecycle(t *testing.T) {
 					resource.TestCheckResourceAttr("runpod_pod.test", "name", "tf-test-pod"),
 					resource.TestCheckResourceAttr("runpod_pod.test", "volume_in_gb", "20"),
 					resource.TestCheckResourceAttr("runpod_pod.test", "gpu_count", "1"),
+					resource.TestCheckResourceAttr("runpod_pod.test", "ports", "8888/http,22/tcp"),
 					resource.TestCheckResourceAttrSet("runpod_pod.test", "id"),
 				),
 			},
-			// Import
+			// Import; ports must round-trip exactly
 			{
 				ResourceName:            "runpod_pod.test",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"gpu_type_id", "cloud_type", "env", "support_public_ip", "start_ssh", "min_vcpu_count", "min_memory_in_gb"},
+				ImportStateVerifyIgnore: []string{"cloud_type", "env", "support_public_ip", "start_ssh", "min_vcpu_count", "min_memory_in_gb"},
 			},
 			// Delete happens automatically
 		},
@@ -43,6 +62,7 @@ resource "runpod_pod" "test" {
   gpu_count          = 1
   volume_in_gb       = %[2]d
   container_disk_in_gb = 20
+  ports              = "8888/http,22/tcp"
 }
 `, name, volumeGb)
 }
@@ -81,3 +101,1828 @@ resource "runpod_pod" "test_env" {
 }
 `
 }
+
+func TestDistributedEnv(t *testing.T) {
+	env := distributedEnv(4)
+
+	expected := map[string]string{
+		"WORLD_SIZE":           "4",
+		"LOCAL_WORLD_SIZE":     "4",
+		"CUDA_VISIBLE_DEVICES": "0,1,2,3",
+	}
+	if len(env) != len(expected) {
+		t.Fatalf("expected %d vars, got %d: %v", len(expected), len(env), env)
+	}
+	for k, v := range expected {
+		if env[k] != v {
+			t.Errorf("expected %s=%q, got %q", k, v, env[k])
+		}
+	}
+}
+
+func TestWaitForPodRunning_minUptime(t *testing.T) {
+	originalInterval := podPollInterval
+	podPollInterval = time.Millisecond
+	t.Cleanup(func() { podPollInterval = originalInterval })
+
+	polls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		fmt.Fprintf(w, `{"data":{"pod":{"id":"pod-1","desiredStatus":"RUNNING","runtime":{"uptimeInSeconds":%d}}}}`, polls*10)
+	})
+
+	pod, err := waitForPodRunning(context.Background(), client, "pod-1", 30, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if polls != 3 {
+		t.Errorf("expected 3 polls, got %d", polls)
+	}
+	if pod.Runtime.UptimeInSeconds != 30 {
+		t.Errorf("expected uptime 30, got %d", pod.Runtime.UptimeInSeconds)
+	}
+}
+
+func TestWaitForPodRunning_timeout(t *testing.T) {
+	originalInterval := podPollInterval
+	podPollInterval = time.Millisecond
+	t.Cleanup(func() { podPollInterval = originalInterval })
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"pod":{"id":"pod-1","desiredStatus":"RUNNING","runtime":{"uptimeInSeconds":5}}}}`)
+	})
+
+	_, err := waitForPodRunning(context.Background(), client, "pod-1", 60, 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if !strings.Contains(err.Error(), "uptime: 5s") {
+		t.Errorf("expected error to include last uptime, got: %s", err)
+	}
+}
+
+func TestMergePersistentEnv(t *testing.T) {
+	current := map[string]string{
+		"RUNPOD_POD_ID": "pod-1",
+		"APP_MODE":      "prod",
+		"OLD_TOKEN":     "old",
+		"SHARED":        "persistent",
+	}
+	env := map[string]string{"APP_MODE": "prod", "SHARED": "from-env"}
+	oldPersistent := map[string]string{"OLD_TOKEN": "old", "SHARED": "persistent"}
+	newPersistent := map[string]string{"API_TOKEN": "new"}
+
+	merged := mergePersistentEnv(current, env, oldPersistent, newPersistent)
+
+	expected := map[string]string{
+		"RUNPOD_POD_ID": "pod-1",
+		"APP_MODE":      "prod",
+		"SHARED":        "from-env",
+		"API_TOKEN":     "new",
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+}
+
+func TestEditPodWithRetry_conflict(t *testing.T) {
+	originalDelay := editConflictRetryDelay
+	editConflictRetryDelay = time.Millisecond
+	t.Cleanup(func() { editConflictRetryDelay = originalDelay })
+
+	reads, edits := 0, 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGraphQLRequest(t, r)
+		if strings.Contains(req.Query, "podEditJob") {
+			edits++
+			if edits == 1 {
+				fmt.Fprint(w, `{"errors":[{"message":"Conflict: pod was modified"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":{"podEditJob":{"id":"pod-1","env":["TOKEN=new"]}}}`)
+			return
+		}
+		reads++
+		fmt.Fprintf(w, `{"data":{"pod":{"id":"pod-1","imageName":"img","env":["READ=%d"]}}}`, reads)
+	})
+
+	var seen []string
+	pod, err := editPodWithRetry(context.Background(), client, "pod-1", func(pod *Pod) *PodEditInput {
+		seen = append(seen, pod.Env[0].Value)
+		input := NewPodEditInput(pod)
+		input.Env = []EnvVar{{Key: "TOKEN", Value: "new"}}
+		return input
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if edits != 2 || reads != 2 {
+		t.Errorf("expected 2 reads and 2 edits, got %d reads and %d edits", reads, edits)
+	}
+	if !reflect.DeepEqual(seen, []string{"1", "2"}) {
+		t.Errorf("expected edit to be recomputed from a fresh read, saw %v", seen)
+	}
+	if pod.Env[0].Value != "new" {
+		t.Errorf("expected edited pod, got %v", pod.Env)
+	}
+}
+
+func TestCreatePod_retriesVolumeNotReady(t *testing.T) {
+	originalDelay := volumeReadyRetryDelay
+	volumeReadyRetryDelay = time.Millisecond
+	t.Cleanup(func() { volumeReadyRetryDelay = originalDelay })
+
+	deploys := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		deploys++
+		if deploys == 1 {
+			fmt.Fprint(w, `{"errors":[{"message":"Network volume is not ready yet"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"podFindAndDeployOnDemand":{"id":"pod-1"}}}`)
+	})
+	r := &PodResource{client: client}
+
+	pod, err := r.createPod(context.Background(), &PodInput{Name: "test", NetworkVolumeID: "vol-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pod.ID != "pod-1" || deploys != 2 {
+		t.Errorf("expected pod-1 after 2 deploys, got %q after %d", pod.ID, deploys)
+	}
+}
+
+func TestGpuTypeMismatchDiagnostics(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"pod":{"id":"pod-1","machine":{"gpuTypeId":"NVIDIA RTX A5000"}}}}`)
+	})
+
+	pod, err := client.GetPod(context.Background(), "pod-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	actual := podGpuTypeID(pod)
+	if actual != "NVIDIA RTX A5000" {
+		t.Fatalf("expected machine GPU type, got %q", actual)
+	}
+
+	diags := gpuTypeMismatchDiagnostics([]string{"NVIDIA RTX A4000"}, actual)
+	if len(diags) != 1 || diags.HasError() {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if diags := gpuTypeMismatchDiagnostics([]string{actual}, actual); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for matching types, got %v", diags)
+	}
+	if diags := gpuTypeMismatchDiagnostics([]string{"NVIDIA RTX A4000", actual}, actual); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for a fallback GPU type, got %v", diags)
+	}
+}
+
+func TestDiskRoundingDiagnostics(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"podFindAndDeployOnDemand":{"id":"pod-1","containerDiskInGb":25,"volumeInGb":20}}`), nil
+	})
+	input := &PodInput{Name: "test", ContainerDiskInGb: 21, VolumeInGb: 20}
+
+	pod, err := client.CreatePod(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	diags := diskRoundingDiagnostics(input, pod)
+	if len(diags) != 1 || diags.HasError() {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail(), "container_disk_in_gb = 25") {
+		t.Errorf("expected the allocated size in the warning, got %q", diags[0].Detail())
+	}
+
+	input.ContainerDiskInGb = 25
+	if diags := diskRoundingDiagnostics(input, pod); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for matching sizes, got %v", diags)
+	}
+}
+
+func TestImageOverrideDiagnostics(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"podFindAndDeployOnDemand":{"id":"pod-1","imageName":"runpod/pytorch:2.1.0"}}`), nil
+	})
+	input := &PodInput{Name: "test", ImageName: "runpod/base:0.4.0", TemplateID: "tmpl-1"}
+
+	pod, err := client.CreatePod(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	diags := imageOverrideDiagnostics(input.ImageName, pod.ImageName, input.TemplateID)
+	if len(diags) != 1 || diags.HasError() {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail(), "template tmpl-1") {
+		t.Errorf("expected the template to be named, got %q", diags[0].Detail())
+	}
+	if diags := imageOverrideDiagnostics(pod.ImageName, pod.ImageName, input.TemplateID); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for matching images, got %v", diags)
+	}
+}
+
+func TestRenderRuntimeEnv(t *testing.T) {
+	pod := &Pod{
+		ID: "pod-1",
+		Runtime: &Runtime{Ports: []Port{
+			{IP: "10.0.0.1", IsIPPublic: false, PrivatePort: 8888, PublicPort: 8888},
+			{IP: "203.0.113.7", IsIPPublic: true, PrivatePort: 8888, PublicPort: 40123},
+		}},
+	}
+
+	got, err := renderRuntimeEnv("http://{{ public_ip }}:{{public_port:8888}}/{{pod_id}}", pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "http://203.0.113.7:40123/pod-1"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, err := renderRuntimeEnv("{{public_port:22}}", pod); err == nil {
+		t.Error("expected an error for an unmapped port")
+	}
+}
+
+func TestPodPortMappings(t *testing.T) {
+	if got := podPortMappings(&Pod{ID: "pod-1"}); got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("expected an empty map while provisioning, got %s", got)
+	}
+
+	pod := &Pod{
+		ID: "pod-1",
+		Runtime: &Runtime{Ports: []Port{
+			{IP: "100.65.0.2", IsIPPublic: false, PrivatePort: 8888, PublicPort: 60001},
+			{IP: "203.0.113.7", IsIPPublic: true, PrivatePort: 22, PublicPort: 40022},
+		}},
+	}
+	got := podPortMappings(pod)
+	want := types.MapValueMust(types.Int64Type, map[string]attr.Value{
+		"22": types.Int64Value(40022),
+	})
+	if !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestPodSSH(t *testing.T) {
+	host, port, command := podSSH(&Pod{ID: "pod-1"})
+	if !host.IsNull() || !port.IsNull() || !command.IsNull() {
+		t.Errorf("expected null SSH attributes while provisioning, got %s, %s, %s", host, port, command)
+	}
+
+	pod := &Pod{
+		ID: "pod-1",
+		Runtime: &Runtime{Ports: []Port{
+			{IP: "100.65.0.2", IsIPPublic: false, PrivatePort: 22, PublicPort: 60022, Type: "tcp"},
+			{IP: "203.0.113.7", IsIPPublic: true, PrivatePort: 8888, PublicPort: 40888, Type: "http"},
+			{IP: "203.0.113.7", IsIPPublic: true, PrivatePort: 22, PublicPort: 40022, Type: "tcp"},
+		}},
+	}
+	host, port, command = podSSH(pod)
+	if host.ValueString() != "203.0.113.7" || port.ValueInt64() != 40022 {
+		t.Errorf("expected 203.0.113.7:40022, got %s:%s", host, port)
+	}
+	if command.ValueString() != "ssh root@203.0.113.7 -p 40022" {
+		t.Errorf("unexpected ssh_command %s", command)
+	}
+
+	private := &Pod{ID: "pod-1", Runtime: &Runtime{Ports: []Port{
+		{IP: "100.65.0.2", IsIPPublic: false, PrivatePort: 22, PublicPort: 60022, Type: "tcp"},
+	}}}
+	if host, _, _ := podSSH(private); !host.IsNull() {
+		t.Errorf("expected no SSH host without a public mapping of port 22, got %s", host)
+	}
+}
+
+func TestPodRuntimePorts(t *testing.T) {
+	provisioning := &Pod{ID: "pod-1"}
+	if got := podRuntimePorts(provisioning); got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("expected an empty list while provisioning, got %s", got)
+	}
+	if got := podPublicIP(provisioning); !got.IsNull() {
+		t.Errorf("expected a null public_ip while provisioning, got %s", got)
+	}
+
+	pod := &Pod{
+		ID: "pod-1",
+		Runtime: &Runtime{Ports: []Port{
+			{IP: "100.65.0.2", IsIPPublic: false, PrivatePort: 8888, PublicPort: 60001, Type: "http"},
+			{IP: "203.0.113.7", IsIPPublic: true, PrivatePort: 22, PublicPort: 40022, Type: "tcp"},
+		}},
+	}
+	got := podRuntimePorts(pod)
+	want := types.ListValueMust(types.ObjectType{AttrTypes: runtimePortAttrTypes}, []attr.Value{
+		types.ObjectValueMust(runtimePortAttrTypes, map[string]attr.Value{
+			"ip":           types.StringValue("100.65.0.2"),
+			"public_port":  types.Int64Value(60001),
+			"private_port": types.Int64Value(8888),
+			"type":         types.StringValue("http"),
+			"is_public":    types.BoolValue(false),
+		}),
+		types.ObjectValueMust(runtimePortAttrTypes, map[string]attr.Value{
+			"ip":           types.StringValue("203.0.113.7"),
+			"public_port":  types.Int64Value(40022),
+			"private_port": types.Int64Value(22),
+			"type":         types.StringValue("tcp"),
+			"is_public":    types.BoolValue(true),
+		}),
+	})
+	if !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if ip := podPublicIP(pod); ip.ValueString() != "203.0.113.7" {
+		t.Errorf("expected the public port's IP, got %s", ip)
+	}
+}
+
+func TestPodDesiredStatus(t *testing.T) {
+	tests := map[string]string{
+		"RUNNING":    "RUNNING",
+		"EXITED":     "STOPPED",
+		"TERMINATED": "",
+		"":           "",
+	}
+	for status, want := range tests {
+		if got := podDesiredStatus(&Pod{DesiredStatus: status}); got != want {
+			t.Errorf("podDesiredStatus(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestResumePod_actualGpuCount(t *testing.T) {
+	var resumedWith interface{}
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		if query != resumePodMutation {
+			t.Fatalf("unexpected query: %s", query)
+		}
+		resumedWith = variables["input"].(map[string]interface{})["gpuCount"]
+		return json.RawMessage(`{"podResume":{"id":"pod-1","desiredStatus":"RUNNING"}}`), nil
+	})
+	r := &PodResource{client: client}
+
+	state := PodResourceModel{
+		ID:             types.StringValue("pod-1"),
+		GpuCount:       types.Int64Value(4),
+		ActualGpuCount: types.Int64Value(2),
+	}
+	plan := state
+	plan.WaitForRunning = types.BoolValue(false)
+
+	if diags := r.resumePod(context.Background(), plan, state); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if resumedWith != 2 {
+		t.Errorf("expected the pod to be resumed with the 2 GPUs it was deployed with, got %v", resumedWith)
+	}
+}
+
+func TestResumePod_interruptible(t *testing.T) {
+	var input map[string]interface{}
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		if query != resumeInterruptiblePodMutation {
+			t.Fatalf("unexpected query: %s", query)
+		}
+		input = variables["input"].(map[string]interface{})
+		return json.RawMessage(`{"podBidResume":{"id":"pod-1","desiredStatus":"RUNNING"}}`), nil
+	})
+	r := &PodResource{client: client}
+
+	state := PodResourceModel{
+		ID:            types.StringValue("pod-1"),
+		GpuCount:      types.Int64Value(1),
+		Interruptible: types.BoolValue(true),
+		BidPerGpu:     types.Float64Value(0.25),
+	}
+	plan := state
+	plan.WaitForRunning = types.BoolValue(false)
+
+	if diags := r.resumePod(context.Background(), plan, state); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if input["bidPerGpu"] != 0.25 || input["gpuCount"] != 1 {
+		t.Errorf("expected a resume bidding $0.25 for 1 GPU, got %v", input)
+	}
+}
+
+func TestCreatePod_interruptible(t *testing.T) {
+	var queries []string
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		queries = append(queries, query)
+		if query == createInterruptiblePodMutation {
+			return json.RawMessage(`{"podRentInterruptable":{"id":"pod-1"}}`), nil
+		}
+		return json.RawMessage(`{"podFindAndDeployOnDemand":{"id":"pod-2"}}`), nil
+	})
+	r := &PodResource{client: client}
+
+	input := &PodInput{Name: "test", GpuCount: 2, BidPerGpu: 0.3}
+	if pod, err := r.createPod(context.Background(), input); err != nil || pod.ID != "pod-1" {
+		t.Fatalf("expected a spot pod, got %v, %v", pod, err)
+	}
+	if pod, err := r.createPod(context.Background(), &PodInput{Name: "test", GpuCount: 2}); err != nil || pod.ID != "pod-2" {
+		t.Fatalf("expected an on-demand pod, got %v, %v", pod, err)
+	}
+	if len(queries) != 2 || queries[0] != createInterruptiblePodMutation || queries[1] != createPodMutation {
+		t.Errorf("expected a spot deploy then an on-demand deploy, got %d queries", len(queries))
+	}
+
+	if cost, ok, err := r.estimatePodCost(context.Background(), input); err != nil || !ok || cost != 0.6 {
+		t.Errorf("expected a spot pod to cost its bid of $0.60/hr, got %v, %v, %v", cost, ok, err)
+	}
+}
+
+func TestInterruptibleDiagnostics(t *testing.T) {
+	tests := []struct {
+		name          string
+		interruptible types.Bool
+		bid           types.Float64
+		wantError     bool
+	}{
+		{"on demand", types.BoolNull(), types.Float64Null(), false},
+		{"spot with bid", types.BoolValue(true), types.Float64Value(0.2), false},
+		{"spot without bid", types.BoolValue(true), types.Float64Null(), true},
+		{"spot with zero bid", types.BoolValue(true), types.Float64Value(0), true},
+		{"bid without spot", types.BoolNull(), types.Float64Value(0.2), true},
+		{"unknown bid", types.BoolValue(true), types.Float64Unknown(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := interruptibleDiagnostics(&PodResourceModel{Interruptible: tt.interruptible, BidPerGpu: tt.bid})
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestMinAcceptableGpuCountDiagnostics(t *testing.T) {
+	tests := []struct {
+		name        string
+		minGpuCount types.Int64
+		gpuCount    types.Int64
+		distributed types.Bool
+		wantError   bool
+	}{
+		{"unset", types.Int64Null(), types.Int64Value(4), types.BoolValue(true), false},
+		{"below gpu_count", types.Int64Value(2), types.Int64Value(4), types.BoolNull(), false},
+		{"above gpu_count", types.Int64Value(5), types.Int64Value(4), types.BoolNull(), true},
+		{"above default gpu_count", types.Int64Value(2), types.Int64Null(), types.BoolNull(), true},
+		{"unknown gpu_count", types.Int64Value(5), types.Int64Unknown(), types.BoolNull(), false},
+		{"unknown minimum", types.Int64Unknown(), types.Int64Value(4), types.BoolValue(true), false},
+		{"with distributed env", types.Int64Value(2), types.Int64Value(4), types.BoolValue(true), true},
+		{"with unknown distributed env", types.Int64Value(2), types.Int64Value(4), types.BoolUnknown(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := minAcceptableGpuCountDiagnostics(&PodResourceModel{
+				MinAcceptableGpuCount: tt.minGpuCount,
+				GpuCount:              tt.gpuCount,
+				InjectDistributedEnv:  tt.distributed,
+			})
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestInlineNetworkVolumeDiagnostics(t *testing.T) {
+	tests := []struct {
+		name         string
+		dataCenterID types.String
+		volumeDC     types.String
+		wantError    bool
+	}{
+		{"same data center", types.StringValue("EU-RO-1"), types.StringValue("EU-RO-1"), false},
+		{"other data center", types.StringValue("US-TX-3"), types.StringValue("EU-RO-1"), true},
+		{"no pod data center", types.StringNull(), types.StringValue("EU-RO-1"), false},
+		{"unknown pod data center", types.StringUnknown(), types.StringValue("EU-RO-1"), false},
+		{"unknown volume data center", types.StringValue("US-TX-3"), types.StringUnknown(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := inlineNetworkVolumeDiagnostics(&PodResourceModel{
+				DataCenterID:        tt.dataCenterID,
+				InlineNetworkVolume: &InlineNetworkVolumeModel{DataCenterID: tt.volumeDC},
+			})
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+
+	if diags := inlineNetworkVolumeDiagnostics(&PodResourceModel{DataCenterID: types.StringValue("US-TX-3")}); diags.HasError() {
+		t.Errorf("expected no error without an inline network volume, got %v", diags)
+	}
+}
+
+func TestPodCloudType(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"pod":{"id":"pod-1","machine":{"secureCloud":true}}}`), nil
+	})
+
+	pod, err := client.GetPod(context.Background(), "pod-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := podCloudType(pod); got != "SECURE" {
+		t.Errorf("expected SECURE, got %q", got)
+	}
+
+	pod.Machine.SecureCloud = false
+	if got := podCloudType(pod); got != "COMMUNITY" {
+		t.Errorf("expected COMMUNITY, got %q", got)
+	}
+
+	pod.Machine = nil
+	if got := podCloudType(pod); got != "" {
+		t.Errorf("expected no cloud type without a machine, got %q", got)
+	}
+}
+
+func TestPodLocation(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"pod":{"id":"pod-1","machine":{"location":"EU-RO"}}}`), nil
+	})
+
+	pod, err := client.GetPod(context.Background(), "pod-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := podLocation(pod); got != "EU-RO" {
+		t.Errorf("expected EU-RO, got %q", got)
+	}
+
+	pod.Machine = nil
+	if got := podLocation(pod); got != "" {
+		t.Errorf("expected no location without a machine, got %q", got)
+	}
+}
+
+func TestPodDiskSizes(t *testing.T) {
+	volumes := `{"myself":{"networkVolumes":[{"id":"vol-1","size":100}]}}`
+	var queries int
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		if query != listNetworkVolumesQuery {
+			t.Fatalf("unexpected query: %s", query)
+		}
+		queries++
+		return json.RawMessage(volumes), nil
+	})
+	r := &PodResource{client: client}
+	data := &PodResourceModel{}
+	pod := &Pod{ID: "pod-1", ContainerDiskInGb: 20, VolumeInGb: 40}
+
+	total, persistent := r.podDiskSizes(context.Background(), data, pod)
+	if total.ValueInt64() != 60 || persistent.ValueInt64() != 40 {
+		t.Errorf("expected 60 GB total with a 40 GB volume, got %s and %s", total, persistent)
+	}
+
+	pod.VolumeInGb = 0
+	pod.NetworkVolumeID = "vol-1"
+	total, persistent = r.podDiskSizes(context.Background(), data, pod)
+	if total.ValueInt64() != 120 || persistent.ValueInt64() != 100 {
+		t.Errorf("expected 120 GB total with a 100 GB network volume, got %s and %s", total, persistent)
+	}
+
+	// A pod read with its network volume needs no lookup
+	queries = 0
+	pod.NetworkVolume = &NetworkVolume{ID: "vol-1", Size: 150}
+	total, persistent = r.podDiskSizes(context.Background(), data, pod)
+	if total.ValueInt64() != 170 || persistent.ValueInt64() != 150 || queries != 0 {
+		t.Errorf("expected the pod's 150 GB network volume without a query, got %s and %s after %d queries", total, persistent, queries)
+	}
+	pod.NetworkVolume = nil
+
+	// A network volume that cannot be found leaves the sizes unknown
+	volumes = `{"myself":{"networkVolumes":[]}}`
+	total, persistent = r.podDiskSizes(context.Background(), data, pod)
+	if !total.IsNull() || !persistent.IsNull() {
+		t.Errorf("expected null sizes without the network volume, got %s and %s", total, persistent)
+	}
+}
+
+func TestPortCountDiagnostics(t *testing.T) {
+	var specs []string
+	for port := 8000; port < 8000+maxExposedPorts; port++ {
+		specs = append(specs, fmt.Sprintf("%d/http", port))
+	}
+	ports := strings.Join(specs, ", ") + ","
+
+	if diags := portCountDiagnostics(path.Root("ports"), ports, true); len(diags) != 0 {
+		t.Errorf("expected no diagnostics at the limit, got %v", diags)
+	}
+
+	ports += "22/tcp"
+	if diags := portCountDiagnostics(path.Root("ports"), ports, false); diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected a warning over the limit, got %v", diags)
+	}
+	diags := portCountDiagnostics(path.Root("exposed_ports"), ports, true)
+	if !diags.HasError() {
+		t.Fatalf("expected an error over the limit when strict, got %v", diags)
+	}
+	if withPath, ok := diags[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("exposed_ports")) {
+		t.Errorf("expected the error on exposed_ports, got %v", diags[0])
+	}
+}
+
+func TestPortsAttribute(t *testing.T) {
+	if got := portsAttribute(types.ListNull(types.ObjectType{AttrTypes: exposedPortAttrTypes})); !got.Equal(path.Root("ports")) {
+		t.Errorf("expected ports without exposed_ports, got %s", got)
+	}
+	exposed := types.ListValueMust(types.ObjectType{AttrTypes: exposedPortAttrTypes}, nil)
+	if got := portsAttribute(exposed); !got.Equal(path.Root("exposed_ports")) {
+		t.Errorf("expected exposed_ports when it is set, got %s", got)
+	}
+}
+
+func TestPortsSpec(t *testing.T) {
+	ctx := context.Background()
+	exposed := types.ListValueMust(types.ObjectType{AttrTypes: exposedPortAttrTypes}, []attr.Value{
+		types.ObjectValueMust(exposedPortAttrTypes, map[string]attr.Value{
+			"port": types.Int64Value(8888), "protocol": types.StringValue("http"),
+		}),
+		types.ObjectValueMust(exposedPortAttrTypes, map[string]attr.Value{
+			"port": types.Int64Value(22), "protocol": types.StringValue("tcp"),
+		}),
+	})
+
+	if spec, known := portsSpec(ctx, types.StringNull(), exposed); !known || spec != "8888/http,22/tcp" {
+		t.Errorf("expected exposed_ports to serialize to 8888/http,22/tcp, got %q (known %v)", spec, known)
+	}
+	if spec, known := portsSpec(ctx, types.StringValue("22/tcp"), types.ListNull(exposed.ElementType(ctx))); !known || spec != "22/tcp" {
+		t.Errorf("expected the ports string, got %q (known %v)", spec, known)
+	}
+	if _, known := portsSpec(ctx, types.StringNull(), types.ListUnknown(exposed.ElementType(ctx))); known {
+		t.Error("expected unknown exposed_ports to be unknown")
+	}
+
+	if got := exposedPortsValue("8888/http, 22/tcp"); !got.Equal(exposed) {
+		t.Errorf("expected %s, got %s", exposed, got)
+	}
+}
+
+func TestOnlyMutableEnvChanged(t *testing.T) {
+	oldEnv := map[string]string{"LOG_LEVEL": "info", "MODEL": "a"}
+	mutable := []string{"LOG_LEVEL", "DEBUG"}
+
+	if !onlyMutableEnvChanged(oldEnv, map[string]string{"LOG_LEVEL": "debug", "MODEL": "a", "DEBUG": "1"}, mutable) {
+		t.Error("expected changes to mutable keys to be applied in place")
+	}
+	if onlyMutableEnvChanged(oldEnv, map[string]string{"LOG_LEVEL": "debug", "MODEL": "b"}, mutable) {
+		t.Error("expected a change to an immutable key to require replacement")
+	}
+	if onlyMutableEnvChanged(oldEnv, map[string]string{"LOG_LEVEL": "info"}, mutable) {
+		t.Error("expected removing an immutable key to require replacement")
+	}
+	if onlyMutableEnvChanged(oldEnv, map[string]string{"LOG_LEVEL": "debug", "MODEL": "a"}, nil) {
+		t.Error("expected every change to require replacement without mutable_env_keys")
+	}
+}
+
+func TestEnvRequiresReplace(t *testing.T) {
+	envValue := func(env map[string]string) tftypes.Value {
+		values := make(map[string]tftypes.Value, len(env))
+		for k, v := range env {
+			values[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, values)
+	}
+	envMap := func(env map[string]string) types.Map {
+		values := make(map[string]attr.Value, len(env))
+		for k, v := range env {
+			values[k] = types.StringValue(v)
+		}
+		return types.MapValueMust(types.StringType, values)
+	}
+
+	oldEnv := map[string]string{"MODEL": "a"}
+	newEnv := map[string]string{"MODEL": "b"}
+	plan := newPodPlan(t, map[string]tftypes.Value{"env": envValue(newEnv)})
+	state := tfsdk.State{Schema: plan.Schema, Raw: newPodPlan(t, map[string]tftypes.Value{"env": envValue(oldEnv)}).Raw}
+
+	req := planmodifier.MapRequest{
+		Path:       path.Root("env"),
+		Plan:       plan,
+		PlanValue:  envMap(newEnv),
+		State:      state,
+		StateValue: envMap(oldEnv),
+	}
+	resp := &planmodifier.MapResponse{PlanValue: req.PlanValue}
+	envRequiresReplace{}.PlanModifyMap(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.RequiresReplace {
+		t.Error("expected an env change without mutable_env_keys to replace the pod")
+	}
+}
+
+func TestReadEnv(t *testing.T) {
+	ctx := context.Background()
+	data := PodResourceModel{
+		Env: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"MODEL":     types.StringValue("a"),
+			"LOG_LEVEL": types.StringValue("info"),
+			"TOKEN":     types.StringValue("from-env"),
+		}),
+		PersistentEnv: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"TOKEN": types.StringValue("secret"),
+		}),
+	}
+	pod := &Pod{ID: "pod-1", Env: EnvVars{
+		{Key: "MODEL", Value: "b"},
+		{Key: "TOKEN", Value: "secret"},
+		{Key: "RUNPOD_POD_ID", Value: "pod-1"},
+	}}
+
+	if diags := readEnv(ctx, &data, pod); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	wantEnv := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"MODEL": types.StringValue("b"),
+		"TOKEN": types.StringValue("from-env"),
+	})
+	if !data.Env.Equal(wantEnv) {
+		t.Errorf("expected env %s, got %s", wantEnv, data.Env)
+	}
+	wantPersistent := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"TOKEN": types.StringValue("secret"),
+	})
+	if !data.PersistentEnv.Equal(wantPersistent) {
+		t.Errorf("expected persistent_env %s, got %s", wantPersistent, data.PersistentEnv)
+	}
+
+	// Without env in the response, state is kept
+	if diags := readEnv(ctx, &data, &Pod{ID: "pod-1"}); diags.HasError() || !data.Env.Equal(wantEnv) {
+		t.Errorf("expected env to be kept when the API returns none, got %s", data.Env)
+	}
+}
+
+func TestReadEnv_ignoreEnvKeys(t *testing.T) {
+	ctx := context.Background()
+	data := PodResourceModel{
+		Env: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"RUNPOD_POD_ID": types.StringValue("mine"),
+			"HF_HOME":       types.StringValue("/workspace/hf"),
+			"MODEL":         types.StringValue("a"),
+		}),
+		PersistentEnv: types.MapNull(types.StringType),
+		IgnoreEnvKeys: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("RUNPOD_.*"),
+			types.StringValue("HF_HOME"),
+		}),
+	}
+	pod := &Pod{ID: "pod-1", Env: EnvVars{
+		{Key: "RUNPOD_POD_ID", Value: "pod-1"},
+		{Key: "MODEL", Value: "b"},
+	}}
+
+	if diags := readEnv(ctx, &data, pod); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"RUNPOD_POD_ID": types.StringValue("mine"),
+		"HF_HOME":       types.StringValue("/workspace/hf"),
+		"MODEL":         types.StringValue("b"),
+	})
+	if !data.Env.Equal(want) {
+		t.Errorf("expected ignored keys to keep their state values, got %s", data.Env)
+	}
+}
+
+func TestEnvKeyMatcher(t *testing.T) {
+	ctx := context.Background()
+	patterns := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("RUNPOD_POD_ID"),
+		types.StringValue("PUBLIC_.*"),
+	})
+
+	matches, diags := envKeyMatcher(ctx, patterns)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	for key, want := range map[string]bool{
+		"RUNPOD_POD_ID":    true,
+		"RUNPOD_POD_ID_2":  false,
+		"PUBLIC_KEY":       true,
+		"MY_PUBLIC_KEY":    false,
+		"RUNPOD_POD_IDENT": false,
+	} {
+		if got := matches(key); got != want {
+			t.Errorf("%s: expected %v, got %v", key, want, got)
+		}
+	}
+
+	if matches, diags := envKeyMatcher(ctx, types.ListNull(types.StringType)); diags.HasError() || matches("ANY") {
+		t.Error("expected no keys to match without ignore_env_keys")
+	}
+
+	invalid := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("RUNPOD_(")})
+	if _, diags := envKeyMatcher(ctx, invalid); !diags.HasError() {
+		t.Error("expected an invalid regular expression to be rejected")
+	}
+}
+
+func TestMergeEnvChanges(t *testing.T) {
+	current := map[string]string{"RUNPOD_POD_ID": "pod-1", "LOG_LEVEL": "info", "DEBUG": "1", "TOKEN": "secret"}
+	oldEnv := map[string]string{"LOG_LEVEL": "info", "DEBUG": "1", "TOKEN": "plain"}
+	newEnv := map[string]string{"LOG_LEVEL": "debug"}
+	persistent := map[string]string{"TOKEN": "secret"}
+
+	merged := mergeEnvChanges(current, oldEnv, newEnv, persistent)
+
+	expected := map[string]string{"RUNPOD_POD_ID": "pod-1", "LOG_LEVEL": "debug", "TOKEN": "secret"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+}
+
+func TestVolumeDestroyGuard(t *testing.T) {
+	data := &PodResourceModel{
+		ID:                       types.StringValue("pod-1"),
+		VolumeInGb:               types.Int64Value(20),
+		PreventDestroyWithVolume: types.BoolValue(true),
+	}
+	if diags := volumeDestroyGuard(data); !diags.HasError() {
+		t.Error("expected destroy to be prevented for a pod with an inline volume")
+	}
+
+	data.VolumeInGb = types.Int64Value(0)
+	if diags := volumeDestroyGuard(data); diags.HasError() {
+		t.Errorf("expected no error without an inline volume, got %v", diags)
+	}
+
+	data.VolumeInGb = types.Int64Value(20)
+	data.PreventDestroyWithVolume = types.BoolValue(false)
+	if diags := volumeDestroyGuard(data); diags.HasError() {
+		t.Errorf("expected no error without the guard, got %v", diags)
+	}
+}
+
+func TestObservedEnvState_partialEdit(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		if strings.Contains(query, "podEditJob") {
+			return nil, fmt.Errorf("GraphQL error: pod edit failed")
+		}
+		// LOG_LEVEL was applied before the failure, DEBUG was not
+		return json.RawMessage(`{"pod":{"id":"pod-1","imageName":"img","env":["RUNPOD_POD_ID=pod-1","LOG_LEVEL=debug","DEBUG=1"]}}`), nil
+	})
+	r := &PodResource{client: client}
+
+	state := PodResourceModel{
+		ID:            types.StringValue("pod-1"),
+		Env:           types.MapValueMust(types.StringType, map[string]attr.Value{"LOG_LEVEL": types.StringValue("info"), "DEBUG": types.StringValue("1")}),
+		PersistentEnv: types.MapNull(types.StringType),
+	}
+	plan := state
+	plan.Env = types.MapValueMust(types.StringType, map[string]attr.Value{"LOG_LEVEL": types.StringValue("debug"), "DEBUG": types.StringValue("2")})
+
+	if diags := r.applyEnv(context.Background(), "pod-1", plan, state); !diags.HasError() {
+		t.Fatal("expected the edit to fail")
+	}
+
+	observed, diags := r.observedEnvState(context.Background(), plan, state)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{"LOG_LEVEL": types.StringValue("debug"), "DEBUG": types.StringValue("1")})
+	if !observed.Env.Equal(want) {
+		t.Errorf("expected observed env %s, got %s", want, observed.Env)
+	}
+	if !observed.PersistentEnv.IsNull() {
+		t.Errorf("expected persistent_env to stay null, got %s", observed.PersistentEnv)
+	}
+}
+
+func TestPortsEquivalent(t *testing.T) {
+	if !portsEquivalent("8888/http, 22/tcp", "22/tcp,8888/HTTP") {
+		t.Error("expected reordered and reformatted ports to be equivalent")
+	}
+	if portsEquivalent("8888/http,22/tcp", "8888/http") {
+		t.Error("expected a removed port to be a change")
+	}
+	if portsEquivalent("8888/http", "8888/tcp") {
+		t.Error("expected a changed protocol to be a change")
+	}
+}
+
+func TestPodUtilization(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"pod":{"id":"pod-1","runtime":{"uptimeInSeconds":60,"container":{"cpuPercent":37,"memoryPercent":82}}}}`), nil
+	})
+
+	pod, err := client.GetPod(context.Background(), "pod-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cpu, memory := podUtilization(pod)
+	if cpu.ValueInt64() != 37 || memory.ValueInt64() != 82 {
+		t.Errorf("expected 37%% CPU and 82%% memory, got %s and %s", cpu, memory)
+	}
+
+	pod.Runtime = nil
+	if cpu, memory := podUtilization(pod); !cpu.IsNull() || !memory.IsNull() {
+		t.Errorf("expected null utilization without a runtime, got %s and %s", cpu, memory)
+	}
+}
+
+func TestPodUptime(t *testing.T) {
+	pod := &Pod{ID: "pod-1", Runtime: &Runtime{UptimeInSeconds: 3600}}
+	if uptime := podUptime(pod); uptime.ValueInt64() != 3600 {
+		t.Errorf("expected 3600 seconds, got %s", uptime)
+	}
+
+	pod.Runtime = nil
+	if uptime := podUptime(pod); !uptime.IsNull() {
+		t.Errorf("expected null uptime without a runtime, got %s", uptime)
+	}
+}
+
+func TestCreatePodWithGpuFallback(t *testing.T) {
+	var requested []interface{}
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		count := variables["input"].(map[string]interface{})["gpuCount"]
+		requested = append(requested, count)
+		if count.(int) > 2 {
+			return nil, fmt.Errorf("GraphQL error: There are no longer any instances available with the requested specifications.")
+		}
+		return json.RawMessage(`{"podFindAndDeployOnDemand":{"id":"pod-1","gpuCount":2}}`), nil
+	})
+	r := &PodResource{client: client}
+
+	input := &PodInput{Name: "test", GpuCount: 4}
+	if _, err := r.createPodWithGpuFallback(context.Background(), input, 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if input.GpuCount != 2 || !reflect.DeepEqual(requested, []interface{}{4, 3, 2}) {
+		t.Errorf("expected deploys with 4, 3 and 2 GPUs, got %v ending at %d", requested, input.GpuCount)
+	}
+
+	requested = nil
+	input = &PodInput{Name: "test", GpuCount: 4}
+	if _, err := r.createPodWithGpuFallback(context.Background(), input, 3); err == nil {
+		t.Error("expected an error when the minimum is not available")
+	}
+	if !reflect.DeepEqual(requested, []interface{}{4, 3}) {
+		t.Errorf("expected no deploy below the minimum, got %v", requested)
+	}
+}
+
+func TestCreatePodWithGpuTypeFallback(t *testing.T) {
+	var requested []string
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		input := variables["input"].(map[string]interface{})
+		gpuTypeID := input["gpuTypeId"].(string)
+		requested = append(requested, fmt.Sprintf("%s x%d", gpuTypeID, input["gpuCount"]))
+		if gpuTypeID != "NVIDIA RTX A5000" {
+			return nil, fmt.Errorf("GraphQL error: There are no longer any instances available with the requested specifications.")
+		}
+		return json.RawMessage(`{"podFindAndDeployOnDemand":{"id":"pod-1"}}`), nil
+	})
+	r := &PodResource{client: client}
+
+	input := &PodInput{
+		Name:       "test",
+		GpuTypeID:  "NVIDIA RTX A6000",
+		GpuTypeIDs: []string{"NVIDIA RTX A6000", "NVIDIA RTX A5000", "NVIDIA RTX A4000"},
+		GpuCount:   2,
+	}
+	if _, err := r.createPodWithGpuTypeFallback(context.Background(), input, 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []string{"NVIDIA RTX A6000 x2", "NVIDIA RTX A6000 x1", "NVIDIA RTX A5000 x2"}
+	if !reflect.DeepEqual(requested, want) {
+		t.Errorf("expected deploys %v, got %v", want, requested)
+	}
+	if input.GpuTypeID != "NVIDIA RTX A5000" || input.GpuCount != 2 {
+		t.Errorf("expected the deployed type and count in the input, got %s x%d", input.GpuTypeID, input.GpuCount)
+	}
+
+	requested = nil
+	input = &PodInput{Name: "test", GpuTypeIDs: []string{"NVIDIA RTX A6000", "NVIDIA RTX A4000"}, GpuCount: 1}
+	if _, err := r.createPodWithGpuTypeFallback(context.Background(), input, 1); err == nil {
+		t.Error("expected an error when no GPU type has capacity")
+	}
+	if len(requested) != 2 {
+		t.Errorf("expected each GPU type to be tried once, got %v", requested)
+	}
+}
+
+func TestRedeployPod(t *testing.T) {
+	var requested []string
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		input := variables["input"].(map[string]interface{})
+		requested = append(requested, fmt.Sprintf("%s x%d", input["gpuTypeId"], input["gpuCount"]))
+		if input["supportPublicIp"] == true {
+			return nil, fmt.Errorf("GraphQL error: There are no longer any instances available with the requested specifications.")
+		}
+		return json.RawMessage(`{"podFindAndDeployOnDemand":{"id":"pod-1"}}`), nil
+	})
+	r := &PodResource{client: client}
+
+	input := &PodInput{
+		Name:            "test",
+		GpuTypeID:       "NVIDIA RTX A6000",
+		GpuTypeIDs:      []string{"NVIDIA RTX A5000"},
+		GpuCount:        2,
+		SupportPublicIP: true,
+	}
+	if _, err := r.createPodWithGpuTypeFallback(context.Background(), input, 1); err == nil {
+		t.Fatal("expected an error when no GPU type has capacity")
+	}
+
+	requested = nil
+	input.SupportPublicIP = false
+	if _, err := r.redeployPod(context.Background(), input, "NVIDIA RTX A6000", 2, 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"NVIDIA RTX A6000 x2"}; !reflect.DeepEqual(requested, want) {
+		t.Errorf("expected the redeploy to start from the requested GPU type and count, got %v", requested)
+	}
+	if input.GpuTypeID != "NVIDIA RTX A6000" || input.GpuCount != 2 {
+		t.Errorf("expected the deployed type and count in the input, got %s x%d", input.GpuTypeID, input.GpuCount)
+	}
+}
+
+func TestRequestedGpuTypeIDs(t *testing.T) {
+	data := PodResourceModel{
+		GpuTypeID: types.StringValue("NVIDIA RTX A5000"),
+		GpuTypeIDs: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("NVIDIA RTX A6000"),
+			types.StringValue("NVIDIA RTX A5000"),
+		}),
+	}
+	ids, diags := requestedGpuTypeIDs(context.Background(), &data)
+	if diags.HasError() || !reflect.DeepEqual(ids, []string{"NVIDIA RTX A5000", "NVIDIA RTX A6000"}) {
+		t.Errorf("expected gpu_type_id first without duplicates, got %v (%v)", ids, diags)
+	}
+
+	data = PodResourceModel{GpuTypeID: types.StringNull(), GpuTypeIDs: types.ListNull(types.StringType)}
+	if _, diags := requestedGpuTypeIDs(context.Background(), &data); !diags.HasError() {
+		t.Error("expected an error without a GPU type")
+	}
+}
+
+func TestLimitGpuTypesToMaxPrice(t *testing.T) {
+	queries := 0
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		queries++
+		if query != listGpuTypesQuery {
+			t.Errorf("expected the cached GPU type list to be used, got %s", query)
+		}
+		return json.RawMessage(`{"gpuTypes":[{"id":"NVIDIA H100 80GB HBM3","securePrice":2.99},{"id":"NVIDIA RTX A4000","securePrice":0.4}]}`), nil
+	})
+	r := &PodResource{client: client}
+
+	input := &PodInput{Name: "test", GpuTypeID: "NVIDIA H100 80GB HBM3", GpuTypeIDs: []string{"NVIDIA RTX A4000"}, GpuCount: 1, CloudType: "SECURE"}
+	diags := r.limitGpuTypesToMaxPrice(context.Background(), input, 1.0)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected a warning for the skipped GPU type, got %v", diags)
+	}
+	if input.GpuTypeID != "NVIDIA RTX A4000" || len(input.GpuTypeIDs) != 0 {
+		t.Errorf("expected only the A4000 to remain, got %q and %v", input.GpuTypeID, input.GpuTypeIDs)
+	}
+
+	if cost, ok, err := r.estimatePodCost(context.Background(), &PodInput{GpuTypeID: "NVIDIA RTX A4000", GpuTypeIDs: []string{"NVIDIA H100 80GB HBM3"}, GpuCount: 1, CloudType: "SECURE"}); err != nil || !ok || cost != 2.99 {
+		t.Errorf("expected the highest price to be estimated, got %v, %v, %v", cost, ok, err)
+	}
+
+	input = &PodInput{Name: "test", GpuTypeID: "NVIDIA H100 80GB HBM3", GpuCount: 1, CloudType: "SECURE"}
+	if diags := r.limitGpuTypesToMaxPrice(context.Background(), input, 1.0); !diags.HasError() {
+		t.Error("expected an error when every GPU type is over the ceiling")
+	}
+	if queries != 1 {
+		t.Errorf("expected the GPU types to be listed once, got %d queries", queries)
+	}
+}
+
+func TestCreatePodWithGpuFallback_configError(t *testing.T) {
+	deploys := 0
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		deploys++
+		return nil, fmt.Errorf("GraphQL error: invalid gpuTypeId")
+	})
+	r := &PodResource{client: client}
+
+	if _, err := r.createPodWithGpuFallback(context.Background(), &PodInput{Name: "test", GpuCount: 4}, 1); err == nil {
+		t.Fatal("expected an error")
+	}
+	if deploys != 1 {
+		t.Errorf("expected no retries for a config error, got %d deploys", deploys)
+	}
+}
+
+func TestEffectiveEnv(t *testing.T) {
+	var queries int
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		queries++
+		return json.RawMessage(`{"myself":{"podTemplates":[
+			{"id":"tpl-1","env":[{"key":"JUPYTER_PASSWORD","value":"template"},{"key":"MODEL","value":"base"}]}
+		]}}`), nil
+	})
+	r := &PodResource{client: client}
+	pod := &Pod{ID: "pod-1", Env: EnvVars{{Key: "MODEL", Value: "tuned"}}}
+
+	var diags diag.Diagnostics
+	env := r.effectiveEnv(context.Background(), types.StringValue("tpl-1"), pod, &diags)
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"JUPYTER_PASSWORD": types.StringValue("template"),
+		"MODEL":            types.StringValue("tuned"),
+	})
+	if diags.HasError() || !env.Equal(want) {
+		t.Errorf("expected %s, got %s (%v)", want, env, diags)
+	}
+
+	env = r.effectiveEnv(context.Background(), types.StringValue("tpl-deleted"), pod, &diags)
+	want = types.MapValueMust(types.StringType, map[string]attr.Value{"MODEL": types.StringValue("tuned")})
+	if !env.Equal(want) {
+		t.Errorf("expected only the pod env for a missing template, got %s", env)
+	}
+	if len(diags) != 1 || diags.HasError() {
+		t.Errorf("expected a single warning for a missing template, got %v", diags)
+	}
+
+	// Reading many pods lists the templates once within the TTL
+	if queries != 1 {
+		t.Errorf("expected the templates to be listed once, got %d queries", queries)
+	}
+	client.templates.fetchedAt = time.Now().Add(-templatesCacheTTL)
+	r.effectiveEnv(context.Background(), types.StringValue("tpl-1"), pod, &diags)
+	if queries != 2 {
+		t.Errorf("expected the templates to be listed again after the TTL, got %d queries", queries)
+	}
+}
+
+func TestReservePodCost_exceedsCap(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"gpuTypes":[{"id":"NVIDIA RTX A4000","securePrice":0.4,"communityPrice":0.3}]}`), nil
+	})
+	WithMaxTotalCostPerHr(1.0)(client)
+	r := &PodResource{client: client}
+
+	cost, diags := r.reservePodCost(context.Background(), &PodInput{Name: "a", GpuTypeID: "NVIDIA RTX A4000", GpuCount: 2, CloudType: "SECURE"})
+	if diags.HasError() || cost != 0.8 {
+		t.Fatalf("expected $0.80/hr reserved, got %v (%v)", cost, diags)
+	}
+
+	if _, diags := r.reservePodCost(context.Background(), &PodInput{Name: "b", GpuTypeID: "NVIDIA RTX A4000", GpuCount: 1, CloudType: "COMMUNITY"}); !diags.HasError() {
+		t.Fatal("expected a pod exceeding the cap to be rejected")
+	}
+
+	client.ReleaseCost(cost)
+	if _, diags := r.reservePodCost(context.Background(), &PodInput{Name: "b", GpuTypeID: "NVIDIA RTX A4000", GpuCount: 1, CloudType: "COMMUNITY"}); diags.HasError() {
+		t.Errorf("expected released cost to free the budget, got %v", diags)
+	}
+}
+
+func TestCheckMaxPrice(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"gpuTypes":[{"id":"NVIDIA RTX A4000","securePrice":0.4,"communityPrice":0}]}`), nil
+	})
+	r := &PodResource{client: client}
+
+	input := &PodInput{Name: "test", GpuTypeID: "NVIDIA RTX A4000", GpuCount: 2, CloudType: "SECURE"}
+	if diags := r.checkMaxPrice(context.Background(), input, 0.5); !diags.HasError() {
+		t.Error("expected $0.80/hr to exceed a $0.50/hr ceiling")
+	}
+	if diags := r.checkMaxPrice(context.Background(), input, 1.0); len(diags) != 0 {
+		t.Errorf("expected $0.80/hr to be under a $1.00/hr ceiling, got %v", diags)
+	}
+
+	input.CloudType = "COMMUNITY"
+	if diags := r.checkMaxPrice(context.Background(), input, 0.5); diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected the check to be skipped with a warning without a price, got %v", diags)
+	}
+}
+
+func TestReserveCost_concurrent(t *testing.T) {
+	client := NewClient("test-key", WithMaxTotalCostPerHr(10))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if client.ReserveCost(1) == nil {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reserved != 10 {
+		t.Errorf("expected exactly 10 reservations under the cap, got %d", reserved)
+	}
+}
+
+func TestKnownCUDAVersion(t *testing.T) {
+	for value, wantWarning := range map[string]bool{"12.4": false, "11.8": false, "10.2": true, "12.4.1": true} {
+		req := validator.StringRequest{Path: path.Root("allowed_cuda_versions").AtListIndex(0), ConfigValue: types.StringValue(value)}
+		resp := &validator.StringResponse{}
+		knownCUDAVersion{}.ValidateString(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Errorf("%s: expected only warnings, got %v", value, resp.Diagnostics)
+		}
+		if got := resp.Diagnostics.WarningsCount() > 0; got != wantWarning {
+			t.Errorf("%s: expected warning %v, got %v", value, wantWarning, resp.Diagnostics)
+		}
+	}
+}
+
+func TestPortsFormat(t *testing.T) {
+	tests := map[string]int{
+		"8888/http,22/tcp":     0,
+		" 8888/HTTP , 22/tcp ": 0,
+		"8888/http,":           0,
+		"8888-http":            1,
+		"8888/http,22/udp":     1,
+		"0/tcp,70000/tcp":      2,
+		"ssh/tcp":              1,
+	}
+	for value, wantErrors := range tests {
+		req := validator.StringRequest{Path: path.Root("ports"), ConfigValue: types.StringValue(value)}
+		resp := &validator.StringResponse{}
+		portsFormat{}.ValidateString(context.Background(), req, resp)
+
+		if got := resp.Diagnostics.ErrorsCount(); got != wantErrors {
+			t.Errorf("%q: expected %d errors, got %v", value, wantErrors, resp.Diagnostics)
+		}
+	}
+
+	req := validator.StringRequest{Path: path.Root("ports"), ConfigValue: types.StringValue("8888/http,8888-http")}
+	resp := &validator.StringResponse{}
+	portsFormat{}.ValidateString(context.Background(), req, resp)
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, `"8888-http"`) {
+		t.Errorf("expected the error to name the offending entry, got %q", detail)
+	}
+}
+
+func TestInlineNetworkVolume_createAndDelete(t *testing.T) {
+	originalDelay := volumeReadyRetryDelay
+	volumeReadyRetryDelay = time.Millisecond
+	t.Cleanup(func() { volumeReadyRetryDelay = originalDelay })
+
+	deletes := 0
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		input := variables["input"]
+		switch {
+		case strings.Contains(query, "createNetworkVolume"):
+			if got := input.(map[string]interface{})["dataCenterId"]; got != "EU-RO-1" {
+				t.Errorf("expected volume in EU-RO-1, got %v", got)
+			}
+			return json.RawMessage(`{"createNetworkVolume":{"id":"vol-1","name":"data","size":50,"dataCenterId":"EU-RO-1"}}`), nil
+		case strings.Contains(query, "deleteNetworkVolume"):
+			if got := input.(map[string]string)["id"]; got != "vol-1" {
+				t.Errorf("expected vol-1 to be deleted, got %s", got)
+			}
+			deletes++
+			if deletes == 1 {
+				return nil, fmt.Errorf("network volume is attached to a pod")
+			}
+			return json.RawMessage(`{"deleteNetworkVolume":null}`), nil
+		}
+		t.Fatalf("unexpected query: %s", query)
+		return nil, nil
+	})
+	r := &PodResource{client: client}
+
+	volume, err := client.CreateNetworkVolume(context.Background(), "data", 50, "EU-RO-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if volume.ID != "vol-1" || volume.DataCenterID != "EU-RO-1" {
+		t.Errorf("unexpected volume: %+v", volume)
+	}
+
+	if diags := r.deleteInlineVolume(context.Background(), volume.ID); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if deletes != 2 {
+		t.Errorf("expected delete to be retried while attached, got %d attempts", deletes)
+	}
+}
+
+func TestDeleteInlineVolume_alreadyDeleted(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return nil, newAPIError(graphQLError{Message: "Network volume not found"})
+	})
+	r := &PodResource{client: client}
+
+	if diags := r.deleteInlineVolume(context.Background(), "vol-1"); diags.HasError() {
+		t.Errorf("expected a deleted volume not to be an error, got %v", diags)
+	}
+}
+
+func TestCreatePod_cancelledTerminates(t *testing.T) {
+	t.Run("pod", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var terminated string
+		client := newFakeClient(func(reqCtx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+			switch {
+			case strings.Contains(query, "podFindAndDeployOnDemand"):
+				// The user cancels the apply while the deploy response is in flight
+				cancel()
+				return json.RawMessage(`{"podFindAndDeployOnDemand":{"id":"pod-1"}}`), nil
+			case strings.Contains(query, "podTerminate"):
+				if reqCtx.Err() != nil {
+					t.Error("expected terminate to use a live context")
+				}
+				terminated = variables["input"].(map[string]string)["podId"]
+				return json.RawMessage(`{"podTerminate":null}`), nil
+			}
+			t.Fatalf("unexpected query: %s", query)
+			return nil, nil
+		})
+		r := &PodResource{client: client}
+
+		pod, err := r.createPod(ctx, &PodInput{Name: "test"})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected cancellation error, got pod %v, error %v", pod, err)
+		}
+		if terminated != "pod-1" {
+			t.Errorf("expected pod-1 to be terminated, got %q", terminated)
+		}
+	})
+
+	t.Run("inline volume", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var terminated, deletedVolume string
+		client := newFakeClient(func(reqCtx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+			switch {
+			case strings.Contains(query, "createNetworkVolume"):
+				return json.RawMessage(`{"createNetworkVolume":{"id":"vol-1","dataCenterId":"EU-RO-1"}}`), nil
+			case strings.Contains(query, "podFindAndDeployOnDemand"):
+				cancel()
+				return json.RawMessage(`{"podFindAndDeployOnDemand":{"id":"pod-1"}}`), nil
+			case strings.Contains(query, "podTerminate"):
+				terminated = variables["input"].(map[string]string)["podId"]
+				return json.RawMessage(`{"podTerminate":null}`), nil
+			case strings.Contains(query, "deleteNetworkVolume"):
+				if reqCtx.Err() != nil {
+					t.Error("expected the volume delete to use a live context")
+				}
+				deletedVolume = variables["input"].(map[string]string)["id"]
+				return json.RawMessage(`{"deleteNetworkVolume":null}`), nil
+			}
+			t.Fatalf("unexpected query: %s", query)
+			return nil, nil
+		})
+		r := &PodResource{client: client}
+
+		plan := newPodPlan(t, map[string]tftypes.Value{
+			"name":                  tftypes.NewValue(tftypes.String, "test"),
+			"image_name":            tftypes.NewValue(tftypes.String, "runpod/base"),
+			"gpu_type_id":           tftypes.NewValue(tftypes.String, "NVIDIA RTX A4000"),
+			"gpu_count":             tftypes.NewValue(tftypes.Number, 1),
+			"inline_network_volume": inlineVolumeValue(t, "vol", 10, "EU-RO-1"),
+		})
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+
+		r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected the cancelled create to fail")
+		}
+		if terminated != "pod-1" {
+			t.Errorf("expected pod-1 to be terminated, got %q", terminated)
+		}
+		if deletedVolume != "vol-1" {
+			t.Errorf("expected vol-1 to be deleted, got %q", deletedVolume)
+		}
+	})
+}
+
+// newPodPlan returns a pod resource plan with the given attribute values and
+// all others null
+func newPodPlan(t *testing.T, values map[string]tftypes.Value) tfsdk.Plan {
+	t.Helper()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	(&PodResource{}).Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+
+	raw := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		raw[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range values {
+		raw[name] = value
+	}
+	return tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, raw)}
+}
+
+func TestCreate_fallbackRedeployFails(t *testing.T) {
+	originalInterval := podPollInterval
+	podPollInterval = time.Millisecond
+	t.Cleanup(func() { podPollInterval = originalInterval })
+
+	deploys := 0
+	volumeDeletes := 0
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		switch {
+		case strings.Contains(query, "gpuTypes"):
+			return json.RawMessage(`{"gpuTypes":[{"id":"NVIDIA RTX A4000","securePrice":0.4}]}`), nil
+		case strings.Contains(query, "createNetworkVolume"):
+			return json.RawMessage(`{"createNetworkVolume":{"id":"vol-1","dataCenterId":"EU-RO-1"}}`), nil
+		case strings.Contains(query, "podFindAndDeployOnDemand"):
+			deploys++
+			if deploys > 1 {
+				return nil, &APIError{Message: "something went wrong"}
+			}
+			return json.RawMessage(`{"podFindAndDeployOnDemand":{"id":"pod-1"}}`), nil
+		case strings.Contains(query, "podTerminate"):
+			return json.RawMessage(`{"podTerminate":null}`), nil
+		case strings.Contains(query, "deleteNetworkVolume"):
+			volumeDeletes++
+			return json.RawMessage(`{"deleteNetworkVolume":null}`), nil
+		case strings.Contains(query, "query Pod("):
+			return json.RawMessage(`{"pod":{"id":"pod-1","desiredStatus":"RUNNING","lastStatusChange":"image pull failed: manifest unknown"}}`), nil
+		}
+		t.Fatalf("unexpected query: %s", query)
+		return nil, nil
+	})
+	WithMaxTotalCostPerHr(1.0)(client)
+	r := &PodResource{client: client}
+
+	plan := newPodPlan(t, map[string]tftypes.Value{
+		"name":                  tftypes.NewValue(tftypes.String, "test"),
+		"image_name":            tftypes.NewValue(tftypes.String, "private/image"),
+		"fallback_image_name":   tftypes.NewValue(tftypes.String, "runpod/base"),
+		"gpu_type_id":           tftypes.NewValue(tftypes.String, "NVIDIA RTX A4000"),
+		"gpu_count":             tftypes.NewValue(tftypes.Number, 1),
+		"cloud_type":            tftypes.NewValue(tftypes.String, "SECURE"),
+		"wait_for_running":      tftypes.NewValue(tftypes.Bool, true),
+		"inline_network_volume": inlineVolumeValue(t, "vol", 10, "EU-RO-1"),
+		"timeouts":              podTimeoutsValue(t, map[string]string{"create": "10ms"}),
+	})
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: plan}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the failed fallback deploy to fail the create")
+	}
+	if deploys != 2 {
+		t.Errorf("expected the fallback image to be deployed, got %d deploys", deploys)
+	}
+	if volumeDeletes != 1 {
+		t.Errorf("expected the inline volume to be deleted once, got %d deletes", volumeDeletes)
+	}
+	if client.budget.total != 0 {
+		t.Errorf("expected the cost reservation to be released, got $%.3f/hr reserved", client.budget.total)
+	}
+}
+
+// inlineVolumeValue returns an inline_network_volume block for newPodPlan
+func inlineVolumeValue(t *testing.T, name string, sizeInGb int, dataCenterID string) tftypes.Value {
+	t.Helper()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	(&PodResource{}).Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	volumeType := objectType.AttributeTypes["inline_network_volume"].(tftypes.Object)
+
+	return tftypes.NewValue(volumeType, map[string]tftypes.Value{
+		"name":           tftypes.NewValue(tftypes.String, name),
+		"size_in_gb":     tftypes.NewValue(tftypes.Number, sizeInGb),
+		"data_center_id": tftypes.NewValue(tftypes.String, dataCenterID),
+	})
+}
+
+func TestRead_partialPod(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// gpuCount and containerDiskInGb are missing from the response
+		fmt.Fprint(w, `{"data":{"p0":{"id":"pod-1","name":"trainer","imageName":"runpod/base","desiredStatus":"RUNNING"}}}`)
+	})
+	r := &PodResource{client: client}
+
+	state := newPodPlan(t, map[string]tftypes.Value{
+		"id":                   tftypes.NewValue(tftypes.String, "pod-1"),
+		"name":                 tftypes.NewValue(tftypes.String, "trainer"),
+		"image_name":           tftypes.NewValue(tftypes.String, "runpod/base"),
+		"gpu_type_id":          tftypes.NewValue(tftypes.String, "NVIDIA RTX A4000"),
+		"gpu_count":            tftypes.NewValue(tftypes.Number, 2),
+		"actual_gpu_count":     tftypes.NewValue(tftypes.Number, 2),
+		"container_disk_in_gb": tftypes.NewValue(tftypes.Number, 50),
+	})
+	req := fwresource.ReadRequest{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+
+	r.Read(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data PodResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.GpuCount.ValueInt64() != 2 || data.ActualGpuCount.ValueInt64() != 2 {
+		t.Errorf("expected the GPU count to be kept, got %s and %s", data.GpuCount, data.ActualGpuCount)
+	}
+	if data.ContainerDiskInGb.ValueInt64() != 50 {
+		t.Errorf("expected the container disk to be kept, got %s", data.ContainerDiskInGb)
+	}
+}
+
+func TestRead_imageNameNormalized(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"p0":{"id":"pod-1","name":"trainer","imageName":"repo/img:latest","gpuCount":1,"desiredStatus":"RUNNING"}}}`)
+	})
+	r := &PodResource{client: client}
+
+	state := newPodPlan(t, map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "pod-1"),
+		"name":                tftypes.NewValue(tftypes.String, "trainer"),
+		"image_name":          tftypes.NewValue(tftypes.String, "repo/img"),
+		"deployed_image_name": tftypes.NewValue(tftypes.String, "repo/img"),
+		"gpu_type_id":         tftypes.NewValue(tftypes.String, "NVIDIA RTX A4000"),
+	})
+	req := fwresource.ReadRequest{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+
+	r.Read(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data PodResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	// image_name forces replacement, so it must still match the configuration
+	if data.ImageName.ValueString() != "repo/img" {
+		t.Errorf("expected image_name to be kept, got %s", data.ImageName)
+	}
+	if data.DeployedImageName.ValueString() != "repo/img:latest" {
+		t.Errorf("expected deployed_image_name to show the API's image, got %s", data.DeployedImageName)
+	}
+}
+
+func TestRead_containerRegistryAuthID(t *testing.T) {
+	ctx := context.Background()
+	registryAuthID := ""
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":{"p0":{"id":"pod-1","name":"trainer","imageName":"runpod/base","gpuCount":1,"desiredStatus":"RUNNING","containerRegistryAuthId":%q}}}`, registryAuthID)
+	})
+	r := &PodResource{client: client}
+
+	state := newPodPlan(t, map[string]tftypes.Value{
+		"id":                         tftypes.NewValue(tftypes.String, "pod-1"),
+		"name":                       tftypes.NewValue(tftypes.String, "trainer"),
+		"image_name":                 tftypes.NewValue(tftypes.String, "runpod/base"),
+		"gpu_type_id":                tftypes.NewValue(tftypes.String, "NVIDIA RTX A4000"),
+		"container_registry_auth_id": tftypes.NewValue(tftypes.String, "auth-1"),
+	})
+	read := func() PodResourceModel {
+		t.Helper()
+		req := fwresource.ReadRequest{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+		resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+		r.Read(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+		var data PodResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		return data
+	}
+
+	if data := read(); data.ContainerRegistryAuthID.ValueString() != "auth-1" {
+		t.Errorf("expected the registry auth to be kept when the API returns none, got %s", data.ContainerRegistryAuthID)
+	}
+
+	registryAuthID = "auth-2"
+	if data := read(); data.ContainerRegistryAuthID.ValueString() != "auth-2" {
+		t.Errorf("expected the API's registry auth, got %s", data.ContainerRegistryAuthID)
+	}
+}
+
+func TestImagesEquivalent(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"repo/img", "repo/img:latest", true},
+		{"ubuntu", "docker.io/library/ubuntu:latest", true},
+		{"runpod/base:0.4.0", "docker.io/runpod/base:0.4.0", true},
+		{"localhost:5000/img", "localhost:5000/img:latest", true},
+		{"repo/img@sha256:abc", "repo/img@sha256:abc", true},
+		{"repo/img", "repo/img:v2", false},
+		{"repo/img:latest", "other/img:latest", false},
+		{"ghcr.io/org/img", "org/img", false},
+	}
+	for _, tt := range tests {
+		if got := imagesEquivalent(tt.a, tt.b); got != tt.want {
+			t.Errorf("imagesEquivalent(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestVolumeMountDiagnostics(t *testing.T) {
+	tests := []struct {
+		name          string
+		volumeInGb    types.Int64
+		mountPath     types.String
+		networkVolume types.String
+		wantErrors    int
+		wantWarnings  int
+	}{
+		{"volume with default path", types.Int64Value(20), types.StringNull(), types.StringNull(), 0, 0},
+		{"volume with path", types.Int64Value(20), types.StringValue("/data"), types.StringNull(), 0, 0},
+		{"volume with empty path", types.Int64Value(20), types.StringValue(""), types.StringNull(), 1, 0},
+		{"path without volume", types.Int64Null(), types.StringValue("/data"), types.StringNull(), 0, 1},
+		{"path for network volume", types.Int64Value(0), types.StringValue("/data"), types.StringValue("vol-1"), 0, 0},
+		{"unknown volume size", types.Int64Unknown(), types.StringValue(""), types.StringNull(), 0, 0},
+		{"neither", types.Int64Null(), types.StringNull(), types.StringNull(), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &PodResourceModel{
+				VolumeInGb:      tt.volumeInGb,
+				VolumeMountPath: tt.mountPath,
+				NetworkVolumeID: tt.networkVolume,
+				TemplateID:      types.StringNull(),
+			}
+			diags := volumeMountDiagnostics(data)
+			if diags.ErrorsCount() != tt.wantErrors || diags.WarningsCount() != tt.wantWarnings {
+				t.Errorf("expected %d errors and %d warnings, got %v", tt.wantErrors, tt.wantWarnings, diags)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_volumeMountPath(t *testing.T) {
+	config := newPodPlan(t, map[string]tftypes.Value{
+		"volume_in_gb":      tftypes.NewValue(tftypes.Number, 20),
+		"volume_mount_path": tftypes.NewValue(tftypes.String, ""),
+	})
+	req := fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}
+	resp := &fwresource.ValidateConfigResponse{}
+
+	(&PodResource{}).ValidateConfig(context.Background(), req, resp)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Errorf("expected an error for a volume without a mount path, got %v", resp.Diagnostics)
+	}
+}
+
+func TestImportState_byName(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		return json.RawMessage(`{"myself":{"pods":[
+			{"id":"pod-1","name":"trainer"},
+			{"id":"pod-2","name":"worker"},
+			{"id":"pod-3","name":"worker"}
+		]}}`), nil
+	})
+	r := &PodResource{client: client}
+
+	importState := func(id string) *fwresource.ImportStateResponse {
+		state := newPodPlan(t, nil)
+		resp := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+		r.ImportState(ctx, fwresource.ImportStateRequest{ID: id}, resp)
+		return resp
+	}
+	importedID := func(resp *fwresource.ImportStateResponse) string {
+		var id types.String
+		resp.State.GetAttribute(ctx, path.Root("id"), &id)
+		return id.ValueString()
+	}
+
+	if resp := importState("name:trainer"); resp.Diagnostics.HasError() || importedID(resp) != "pod-1" {
+		t.Errorf("expected pod-1, got %q (%v)", importedID(resp), resp.Diagnostics)
+	}
+	if resp := importState("pod-2"); resp.Diagnostics.HasError() || importedID(resp) != "pod-2" {
+		t.Errorf("expected an ID without the prefix to pass through, got %q (%v)", importedID(resp), resp.Diagnostics)
+	}
+	if resp := importState("name:worker"); !resp.Diagnostics.HasError() {
+		t.Error("expected an error for a name matching several pods")
+	}
+	if resp := importState("name:missing"); !resp.Diagnostics.HasError() {
+		t.Error("expected an error for a name matching no pod")
+	}
+}
+
+func TestModifyPlan_stableName(t *testing.T) {
+	plan := newPodPlan(t, map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "trainer"),
+		"ports": tftypes.NewValue(tftypes.String, "8888/http"),
+	})
+	req := fwresource.ModifyPlanRequest{Plan: plan}
+	resp := &fwresource.ModifyPlanResponse{Plan: plan}
+
+	(&PodResource{}).ModifyPlan(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var stableName types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(context.Background(), path.Root("stable_name"), &stableName)...)
+	if stableName.ValueString() != "trainer" {
+		t.Errorf("expected stable_name to be planned from name, got %s", stableName)
+	}
+}
+
+// podTimeoutsValue returns a timeouts block with the given values and all
+// others null
+func podTimeoutsValue(t *testing.T, values map[string]string) tftypes.Value {
+	t.Helper()
+
+	attrTypes := map[string]tftypes.Type{}
+	raw := map[string]tftypes.Value{}
+	for _, name := range []string{"create", "read", "update", "delete"} {
+		attrTypes[name] = tftypes.String
+		raw[name] = tftypes.NewValue(tftypes.String, nil)
+		if value, ok := values[name]; ok {
+			raw[name] = tftypes.NewValue(tftypes.String, value)
+		}
+	}
+	return tftypes.NewValue(tftypes.Object{AttributeTypes: attrTypes}, raw)
+}
+
+func TestPodTimeouts(t *testing.T) {
+	ctx := context.Background()
+
+	var unset timeouts.Value
+	if got, diags := unset.Create(ctx, defaultPodStartTimeout); diags.HasError() || got != defaultPodStartTimeout {
+		t.Errorf("expected default without a timeouts block, got %s (%v)", got, diags)
+	}
+
+	plan := newPodPlan(t, map[string]tftypes.Value{
+		"timeouts": podTimeoutsValue(t, map[string]string{"create": "25m", "update": "0"}),
+	})
+	var data PodResourceModel
+	if diags := plan.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got, _ := data.Timeouts.Create(ctx, defaultPodStartTimeout); got != 25*time.Minute {
+		t.Errorf("expected 25m, got %s", got)
+	}
+	if got, _ := data.Timeouts.Read(ctx, defaultReadTimeout); got != defaultReadTimeout {
+		t.Errorf("expected read default when unset, got %s", got)
+	}
+	if got, _ := data.Timeouts.Update(ctx, defaultUpdateTimeout); got != 0 {
+		t.Errorf("expected a zero update timeout, got %s", got)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline for a zero timeout")
+	}
+
+	ctx, cancel = withTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, ok := ctx.Deadline(); !ok {
+		t.Error("expected a deadline")
+	}
+}
+
+func TestResumePod_zeroUpdateTimeout(t *testing.T) {
+	var queries []string
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		queries = append(queries, query)
+		return json.RawMessage(`{"podResume":{"id":"pod-1","desiredStatus":"RUNNING"}}`), nil
+	})
+	r := &PodResource{client: client}
+
+	planned := newPodPlan(t, map[string]tftypes.Value{
+		"timeouts": podTimeoutsValue(t, map[string]string{"update": "0"}),
+	})
+	var plan PodResourceModel
+	if diags := planned.Get(context.Background(), &plan); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	plan.ID = types.StringValue("pod-1")
+	plan.GpuCount = types.Int64Value(1)
+	plan.WaitForRunning = types.BoolValue(true)
+	state := plan
+
+	if diags := r.resumePod(context.Background(), plan, state); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(queries) != 1 || queries[0] != resumePodMutation {
+		t.Errorf("expected only the resume mutation without waiting, got %d queries", len(queries))
+	}
+}
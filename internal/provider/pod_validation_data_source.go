@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure interface compliance
+var _ datasource.DataSource = &PodValidationDataSource{}
+
+func NewPodValidationDataSource() datasource.DataSource {
+	return &PodValidationDataSource{}
+}
+
+// PodValidationDataSource defines the data source implementation
+type PodValidationDataSource struct {
+	client *Client
+}
+
+// PodValidationDataSourceModel describes the data source data model
+type PodValidationDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ImageName    types.String `tfsdk:"image_name"`
+	TemplateID   types.String `tfsdk:"template_id"`
+	GpuTypeID    types.String `tfsdk:"gpu_type_id"`
+	GpuCount     types.Int64  `tfsdk:"gpu_count"`
+	CloudType    types.String `tfsdk:"cloud_type"`
+	DataCenterID types.String `tfsdk:"data_center_id"`
+	Ports        types.String `tfsdk:"ports"`
+	Valid        types.Bool   `tfsdk:"valid"`
+	Messages     types.List   `tfsdk:"messages"`
+}
+
+func (d *PodValidationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pod_validation"
+}
+
+func (d *PodValidationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks whether a pod spec is likely to deploy, without deploying it. RunPod has no dry-run deploy, " +
+			"so the check is best effort: input constraints are checked locally, and the GPU type, its stock, the data " +
+			"center and the template against the current API data.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for this data source.",
+				Computed:    true,
+			},
+			"image_name": schema.StringAttribute{
+				Description: "The Docker image to deploy. One of image_name or template_id must be set.",
+				Optional:    true,
+			},
+			"template_id": schema.StringAttribute{
+				Description: "The ID of the template to deploy from.",
+				Optional:    true,
+			},
+			"gpu_type_id": schema.StringAttribute{
+				Description: "The GPU type ID (e.g., 'NVIDIA RTX A4000').",
+				Required:    true,
+			},
+			"gpu_count": schema.Int64Attribute{
+				Description: "The number of GPUs. Defaults to 1.",
+				Optional:    true,
+			},
+			"cloud_type": schema.StringAttribute{
+				Description: "The cloud type (ALL, SECURE, or COMMUNITY). Defaults to ALL.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ALL", "SECURE", "COMMUNITY"),
+				},
+			},
+			"data_center_id": schema.StringAttribute{
+				Description: "The data center to deploy in.",
+				Optional:    true,
+			},
+			"ports": schema.StringAttribute{
+				Description: "The ports to expose (e.g., '8888/http,22/tcp').",
+				Optional:    true,
+				Validators: []validator.String{
+					portsFormat{},
+				},
+			},
+			"valid": schema.BoolAttribute{
+				Description: "Whether no problems were found.",
+				Computed:    true,
+			},
+			"messages": schema.ListAttribute{
+				Description: "A description of each problem found. Empty when valid.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *PodValidationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PodValidationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, d.client)
+
+	var data PodValidationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := PodInput{
+		ImageName:    data.ImageName.ValueString(),
+		TemplateID:   data.TemplateID.ValueString(),
+		GpuTypeID:    data.GpuTypeID.ValueString(),
+		GpuCount:     1,
+		CloudType:    "ALL",
+		DataCenterID: data.DataCenterID.ValueString(),
+		Ports:        data.Ports.ValueString(),
+	}
+	if !data.GpuCount.IsNull() {
+		input.GpuCount = int(data.GpuCount.ValueInt64())
+	}
+	if !data.CloudType.IsNull() {
+		input.CloudType = data.CloudType.ValueString()
+	}
+
+	tflog.Debug(ctx, "Validating pod input", map[string]interface{}{"gpu_type_id": input.GpuTypeID})
+
+	messages, err := d.client.ValidatePodInput(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to validate pod input: %s", err))
+		return
+	}
+	if messages == nil {
+		messages = []string{}
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, messages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Messages = list
+	data.Valid = types.BoolValue(len(messages) == 0)
+	data.ID = types.StringValue("pod_validation")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
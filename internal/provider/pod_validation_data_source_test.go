@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidatePodInput(t *testing.T) {
+	client := newFakeClient(func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+		switch query {
+		case listGpuTypesQuery:
+			return json.RawMessage(`{"gpuTypes":[
+				{"id":"NVIDIA RTX A4000","secureCloud":true,"communityCloud":true,"secureLowestPrice":{"maxUnreservedGpuCount":2}},
+				{"id":"NVIDIA H100 80GB HBM3","secureCloud":true,"communityCloud":false}
+			]}`), nil
+		case listDataCentersQuery:
+			return json.RawMessage(`{"dataCenters":[
+				{"id":"EU-RO-1","gpuAvailability":[{"gpuTypeId":"NVIDIA RTX A4000","available":true}]},
+				{"id":"US-TX-3","gpuAvailability":[{"gpuTypeId":"NVIDIA RTX A4000","available":false}]}
+			]}`), nil
+		case getTemplateQuery:
+			return json.RawMessage(`{"myself":{"podTemplates":[{"id":"tpl-1"}]}}`), nil
+		}
+		t.Fatalf("unexpected query: %s", query)
+		return nil, nil
+	})
+
+	tests := map[string]struct {
+		input PodInput
+		want  []string
+	}{
+		"valid": {
+			input: PodInput{ImageName: "img", GpuTypeID: "NVIDIA RTX A4000", GpuCount: 2, CloudType: "ALL", DataCenterID: "EU-RO-1", TemplateID: "tpl-1"},
+		},
+		"input constraints": {
+			input: PodInput{GpuTypeID: "NVIDIA RTX A4000", GpuCount: 0, CloudType: "ALL", Ports: "1/tcp,2/tcp,3/tcp,4/tcp,5/tcp,6/tcp,7/tcp,8/tcp,9/tcp,10/tcp,11/tcp"},
+			want: []string{
+				"one of image_name or template_id must be set",
+				"gpu_count must be at least 1, got 0",
+				"ports exposes 11 ports, but RunPod exposes at most 10 per pod",
+			},
+		},
+		"unknown GPU type and template": {
+			input: PodInput{GpuTypeID: "NVIDIA RTX A4001", GpuCount: 1, CloudType: "ALL", TemplateID: "tpl-2"},
+			want:  []string{`GPU type "NVIDIA RTX A4001" does not exist`, `template "tpl-2" does not exist`},
+		},
+		"not enough stock": {
+			input: PodInput{ImageName: "img", GpuTypeID: "NVIDIA RTX A4000", GpuCount: 4, CloudType: "SECURE"},
+			want:  []string{"only 2 unreserved NVIDIA RTX A4000 GPUs are available, but gpu_count is 4"},
+		},
+		"unknown stock": {
+			input: PodInput{ImageName: "img", GpuTypeID: "NVIDIA H100 80GB HBM3", GpuCount: 8, CloudType: "ALL"},
+		},
+		"wrong cloud": {
+			input: PodInput{ImageName: "img", GpuTypeID: "NVIDIA H100 80GB HBM3", GpuCount: 1, CloudType: "COMMUNITY"},
+			want:  []string{`GPU type "NVIDIA H100 80GB HBM3" is not offered on community cloud`},
+		},
+		"data center": {
+			input: PodInput{ImageName: "img", GpuTypeID: "NVIDIA RTX A4000", GpuCount: 1, CloudType: "ALL", DataCenterID: "US-TX-3"},
+			want:  []string{`GPU type "NVIDIA RTX A4000" is not currently available in data center "US-TX-3"`},
+		},
+		"unknown data center": {
+			input: PodInput{ImageName: "img", GpuTypeID: "NVIDIA RTX A4000", GpuCount: 1, CloudType: "ALL", DataCenterID: "XX-1"},
+			want:  []string{`data center "XX-1" does not exist`},
+		},
+	}
+	for name, tt := range tests {
+		got, err := client.ValidatePodInput(context.Background(), tt.input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %q, got %q", name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure interface compliance
+var _ datasource.DataSource = &PodsDataSource{}
+
+func NewPodsDataSource() datasource.DataSource {
+	return &PodsDataSource{}
+}
+
+// PodsDataSource defines the data source implementation
+type PodsDataSource struct {
+	client *Client
+}
+
+// PodsDataSourceModel describes the data source data model
+type PodsDataSourceModel struct {
+	ID            types.String       `tfsdk:"id"`
+	DesiredStatus types.String       `tfsdk:"desired_status"`
+	Pods          []PodsSummaryModel `tfsdk:"pods"`
+}
+
+type PodsSummaryModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	ImageName     types.String `tfsdk:"image_name"`
+	GpuCount      types.Int64  `tfsdk:"gpu_count"`
+	DesiredStatus types.String `tfsdk:"desired_status"`
+}
+
+func (d *PodsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pods"
+}
+
+func (d *PodsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the pods in the account.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for this data source.",
+				Computed:    true,
+			},
+			"desired_status": schema.StringAttribute{
+				Description: "Only list pods with this desired status (RUNNING, EXITED or TERMINATED). Lists every pod when not set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("RUNNING", "EXITED", "TERMINATED"),
+				},
+			},
+			"pods": schema.ListNestedAttribute{
+				Description: "The pods in the account.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the pod.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the pod.",
+							Computed:    true,
+						},
+						"image_name": schema.StringAttribute{
+							Description: "The Docker image the pod runs.",
+							Computed:    true,
+						},
+						"gpu_count": schema.Int64Attribute{
+							Description: "The number of GPUs attached to the pod.",
+							Computed:    true,
+						},
+						"desired_status": schema.StringAttribute{
+							Description: "The status RunPod is driving the pod towards (e.g., RUNNING, EXITED).",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PodsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PodsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, d.client)
+
+	var data PodsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading pods")
+
+	pods, err := d.client.ListPods(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to list pods: %s", err))
+		return
+	}
+
+	data.Pods = podsSummary(pods, data.DesiredStatus.ValueString())
+	data.ID = types.StringValue("pods")
+
+	tflog.Trace(ctx, "Read pods", map[string]interface{}{
+		"count": len(data.Pods),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// podsSummary converts the pods with the given desired status, or every pod
+// when desiredStatus is empty
+func podsSummary(pods []Pod, desiredStatus string) []PodsSummaryModel {
+	summary := []PodsSummaryModel{}
+	for _, pod := range pods {
+		if desiredStatus != "" && pod.DesiredStatus != desiredStatus {
+			continue
+		}
+		summary = append(summary, PodsSummaryModel{
+			ID:            types.StringValue(pod.ID),
+			Name:          types.StringValue(pod.Name),
+			ImageName:     types.StringValue(pod.ImageName),
+			GpuCount:      types.Int64Value(int64(pod.GpuCount)),
+			DesiredStatus: types.StringValue(pod.DesiredStatus),
+		})
+	}
+	return summary
+}
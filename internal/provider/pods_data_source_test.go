@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPodsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPodsDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.runpod_pods.running", "id", "pods"),
+					resource.TestCheckResourceAttrSet("data.runpod_pods.running", "pods.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPodsDataSourceConfig() string {
+	return `
+data "runpod_pods" "running" {
+  desired_status = "RUNNING"
+}
+`
+}
+
+func TestPodsSummary(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"myself":{"pods":[
+			{"id":"pod-1","name":"train","imageName":"runpod/pytorch:latest","desiredStatus":"RUNNING","gpuCount":2},
+			{"id":"pod-2","name":"idle","imageName":"runpod/base:latest","desiredStatus":"EXITED","gpuCount":1}
+		]}}}`)
+	})
+
+	pods, err := client.ListPods(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	all := podsSummary(pods, "")
+	if len(all) != 2 {
+		t.Fatalf("expected 2 pods without a filter, got %d", len(all))
+	}
+	if all[0].ImageName.ValueString() != "runpod/pytorch:latest" || all[0].GpuCount.ValueInt64() != 2 {
+		t.Errorf("unexpected summary: %+v", all[0])
+	}
+
+	stopped := podsSummary(pods, "EXITED")
+	if len(stopped) != 1 || stopped[0].ID.ValueString() != "pod-2" {
+		t.Errorf("expected only pod-2, got %+v", stopped)
+	}
+
+	if none := podsSummary(pods, "TERMINATED"); none == nil || len(none) != 0 {
+		t.Errorf("expected an empty list, got %+v", none)
+	}
+}
+
+func TestListPods_largeAccount(t *testing.T) {
+	const podCount = 500
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		pods := make([]string, podCount)
+		for i := range pods {
+			pods[i] = fmt.Sprintf(`{"id":"pod-%d","name":"worker-%d","desiredStatus":"RUNNING","gpuCount":1}`, i, i)
+		}
+		fmt.Fprintf(w, `{"data":{"myself":{"pods":[%s]}}}`, strings.Join(pods, ","))
+	})
+
+	pods, err := client.ListPods(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(pods) != podCount || pods[podCount-1].ID != fmt.Sprintf("pod-%d", podCount-1) {
+		t.Errorf("expected all %d pods, got %d", podCount, len(pods))
+	}
+	if requests != 1 {
+		t.Errorf("expected a single request, got %d", requests)
+	}
+}
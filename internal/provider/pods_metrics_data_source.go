@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure interface compliance
+var _ datasource.DataSource = &PodsMetricsDataSource{}
+
+func NewPodsMetricsDataSource() datasource.DataSource {
+	return &PodsMetricsDataSource{}
+}
+
+// PodsMetricsDataSource defines the data source implementation
+type PodsMetricsDataSource struct {
+	client *Client
+}
+
+// PodsMetricsDataSourceModel describes the data source data model
+type PodsMetricsDataSourceModel struct {
+	ID   types.String      `tfsdk:"id"`
+	Pods []PodMetricsModel `tfsdk:"pods"`
+}
+
+type PodMetricsModel struct {
+	ID            types.String  `tfsdk:"id"`
+	Name          types.String  `tfsdk:"name"`
+	Status        types.String  `tfsdk:"status"`
+	GpuCount      types.Int64   `tfsdk:"gpu_count"`
+	GpuType       types.String  `tfsdk:"gpu_type"`
+	UptimeSeconds types.Int64   `tfsdk:"uptime_seconds"`
+	CostPerHr     types.Float64 `tfsdk:"cost_per_hr"`
+}
+
+func (d *PodsMetricsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pods_metrics"
+}
+
+func (d *PodsMetricsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all pods in the account as a flat set of attributes suitable for feeding a metrics exporter.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for this data source.",
+				Computed:    true,
+			},
+			"pods": schema.ListNestedAttribute{
+				Description: "All pods in the account.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the pod.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the pod.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The desired status of the pod (e.g., 'RUNNING', 'EXITED').",
+							Computed:    true,
+						},
+						"gpu_count": schema.Int64Attribute{
+							Description: "The number of GPUs attached to the pod.",
+							Computed:    true,
+						},
+						"gpu_type": schema.StringAttribute{
+							Description: "The GPU type the pod is running on. Empty if the pod has no machine.",
+							Computed:    true,
+						},
+						"uptime_seconds": schema.Int64Attribute{
+							Description: "The container uptime in seconds. Zero when the pod is not running.",
+							Computed:    true,
+						},
+						"cost_per_hr": schema.Float64Attribute{
+							Description: "The hourly cost of the pod in USD.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PodsMetricsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PodsMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer appendGraphQLWarnings(&resp.Diagnostics, d.client)
+
+	var data PodsMetricsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading pod metrics")
+
+	pods, err := d.client.ListPods(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error",
+			fmt.Sprintf("Unable to list pods: %s", err))
+		return
+	}
+
+	data.Pods = make([]PodMetricsModel, len(pods))
+	for i := range pods {
+		data.Pods[i] = podMetrics(&pods[i])
+	}
+	data.ID = types.StringValue("pods_metrics")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// podMetrics flattens a pod into its metrics attributes
+func podMetrics(pod *Pod) PodMetricsModel {
+	uptime := 0
+	if pod.Runtime != nil {
+		uptime = pod.Runtime.UptimeInSeconds
+	}
+
+	return PodMetricsModel{
+		ID:            types.StringValue(pod.ID),
+		Name:          types.StringValue(pod.Name),
+		Status:        types.StringValue(pod.DesiredStatus),
+		GpuCount:      types.Int64Value(int64(pod.GpuCount)),
+		GpuType:       types.StringValue(podGpuTypeID(pod)),
+		UptimeSeconds: types.Int64Value(int64(uptime)),
+		CostPerHr:     types.Float64Value(pod.CostPerHr),
+	}
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPodsMetricsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPodsMetricsDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.runpod_pods_metrics.all", "id", "pods_metrics"),
+					resource.TestCheckResourceAttrSet("data.runpod_pods_metrics.all", "pods.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPodsMetricsDataSourceConfig() string {
+	return `
+data "runpod_pods_metrics" "all" {
+}
+`
+}
+
+func TestPodMetrics(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"myself":{"pods":[
+			{"id":"pod-1","name":"train","desiredStatus":"RUNNING","gpuCount":2,"costPerHr":0.88,
+			 "machine":{"gpuTypeId":"NVIDIA RTX A5000"},"runtime":{"uptimeInSeconds":120}},
+			{"id":"pod-2","name":"idle","desiredStatus":"EXITED","gpuCount":1,"costPerHr":0,"machine":null,"runtime":null}
+		]}}}`)
+	})
+
+	pods, err := client.ListPods(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(pods) != 2 {
+		t.Fatalf("expected 2 pods, got %d", len(pods))
+	}
+
+	running := podMetrics(&pods[0])
+	if running.GpuType.ValueString() != "NVIDIA RTX A5000" || running.UptimeSeconds.ValueInt64() != 120 || running.CostPerHr.ValueFloat64() != 0.88 {
+		t.Errorf("unexpected metrics for running pod: %+v", running)
+	}
+
+	stopped := podMetrics(&pods[1])
+	if stopped.Status.ValueString() != "EXITED" || stopped.UptimeSeconds.ValueInt64() != 0 || stopped.GpuType.ValueString() != "" {
+		t.Errorf("unexpected metrics for stopped pod: %+v", stopped)
+	}
+}
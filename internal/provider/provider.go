@@ -2,17 +2,43 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ provider.Provider = &RunpodProvider{}
 
+// pingTimeout bounds how long Configure waits for the API to respond
+const pingTimeout = 30 * time.Second
+
+// headerNameRegexp matches valid HTTP header field names (RFC 7230 tokens)
+var headerNameRegexp = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+
+// reservedHeaders are set by the client and cannot be overridden by request_headers
+var reservedHeaders = map[string]bool{
+	"Content-Type":  true,
+	"Authorization": true,
+}
+
 // RunpodProvider defines the provider implementation
 type RunpodProvider struct {
 	version string
@@ -20,7 +46,21 @@ type RunpodProvider struct {
 
 // RunpodProviderModel describes the provider data model
 type RunpodProviderModel struct {
-	APIKey types.String `tfsdk:"api_key"`
+	APIKey                 types.String  `tfsdk:"api_key"`
+	IdleConnTimeoutSeconds types.Int64   `tfsdk:"idle_conn_timeout_seconds"`
+	RequestHeaders         types.Map     `tfsdk:"request_headers"`
+	BaseURL                types.String  `tfsdk:"base_url"`
+	GraphQLPath            types.String  `tfsdk:"graphql_path"`
+	RequestTimeoutSeconds  types.Int64   `tfsdk:"request_timeout_seconds"`
+	MaxRetries             types.Int64   `tfsdk:"max_retries"`
+	MaxConcurrentRequests  types.Int64   `tfsdk:"max_concurrent_requests"`
+	RetryBaseDelayMs       types.Int64   `tfsdk:"retry_base_delay_ms"`
+	RateLimitRetryDelay    types.Int64   `tfsdk:"rate_limit_retry_delay_seconds"`
+	UnavailableRetryDelay  types.Int64   `tfsdk:"unavailable_retry_delay_seconds"`
+	MaxTotalCostPerHr      types.Float64 `tfsdk:"max_total_cost_per_hr"`
+	DebugLogMaxBytes       types.Int64   `tfsdk:"debug_log_max_bytes"`
+	GraphQLWarningPatterns types.List    `tfsdk:"graphql_warning_patterns"`
+	StrictPortLimit        types.Bool    `tfsdk:"strict_port_limit"`
 }
 
 // New returns a new provider instance
@@ -44,6 +84,107 @@ func (p *RunpodProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"request_headers": schema.MapAttribute{
+				Description: "Additional HTTP headers to send with every API request, e.g. for API gateways. Content-Type and Authorization cannot be overridden.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(headerNameRegexp, "must be a valid HTTP header name")),
+				},
+			},
+			"base_url": schema.StringAttribute{
+				Description: "Base URL of the RunPod API, for a proxy, a regional endpoint or a local stub. Either the host, " +
+					"such as https://api.runpod.io, or the full GraphQL endpoint, such as https://api.runpod.io/graphql. " +
+					"Can also be set via RUNPOD_API_URL environment variable. Defaults to https://api.runpod.io.",
+				Optional: true,
+			},
+			"graphql_path": schema.StringAttribute{
+				Description: "Path of the GraphQL endpoint on the API host, for gateways that serve the API elsewhere. " +
+					"Must match the path of base_url when that is a full endpoint. Defaults to /graphql.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must start with /"),
+				},
+			},
+			"request_timeout_seconds": schema.Int64Attribute{
+				Description: "How long a single API request may take, in seconds. Defaults to 60.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "How many times a rate-limited (HTTP 429) or unavailable (HTTP 503) request is retried. " +
+					"0 disables retries, and at most 10 are allowed. Defaults to 4.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+					int64validator.AtMost(maxRetriesLimit),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "How many API requests may be in flight at once. Lower it if parallel applies are rate-limited. Defaults to 4.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_base_delay_ms": schema.Int64Attribute{
+				Description: "Base delay in milliseconds of the exponential backoff between retries. " +
+					"rate_limit_retry_delay_seconds and unavailable_retry_delay_seconds take precedence for their status. Defaults to 2000.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"rate_limit_retry_delay_seconds": schema.Int64Attribute{
+				Description: "Base delay of the exponential backoff when the API rate limits a request (HTTP 429). Defaults to 2.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"unavailable_retry_delay_seconds": schema.Int64Attribute{
+				Description: "Base delay of the exponential backoff when the API is unavailable (HTTP 503). Defaults to 2.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_total_cost_per_hr": schema.Float64Attribute{
+				Description: "Maximum estimated hourly cost in USD of the pods created in a single apply. A pod that would " +
+					"exceed it fails before being deployed. Estimates use the current on-demand GPU price.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"debug_log_max_bytes": schema.Int64Attribute{
+				Description: "Maximum size of each request and response body logged with TF_LOG=DEBUG. Longer bodies are " +
+					"truncated. Set to 0 to log bodies in full. Defaults to 4096.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"graphql_warning_patterns": schema.ListAttribute{
+				Description: "Regular expressions matching GraphQL error messages to report as warnings instead of failing " +
+					"the request, for deprecation or informational messages the API returns as errors. By default every " +
+					"GraphQL error is fatal.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"strict_port_limit": schema.BoolAttribute{
+				Description: "Fail the plan instead of warning when a pod exposes more ports than RunPod allows. Defaults to false.",
+				Optional:    true,
+			},
+			"idle_conn_timeout_seconds": schema.Int64Attribute{
+				Description: "How long an idle HTTP connection to the API is kept open before it is recycled. Defaults to 30.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -70,13 +211,93 @@ func (p *RunpodProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	var opts []ClientOption
+	if !config.IdleConnTimeoutSeconds.IsNull() {
+		opts = append(opts, WithIdleConnTimeout(time.Duration(config.IdleConnTimeoutSeconds.ValueInt64())*time.Second))
+	}
+
+	if !config.RequestTimeoutSeconds.IsNull() {
+		opts = append(opts, WithRequestTimeout(time.Duration(config.RequestTimeoutSeconds.ValueInt64())*time.Second))
+	}
+	if !config.MaxRetries.IsNull() {
+		opts = append(opts, WithMaxRetries(int(config.MaxRetries.ValueInt64())))
+	}
+	if !config.MaxConcurrentRequests.IsNull() {
+		opts = append(opts, WithMaxConcurrentRequests(int(config.MaxConcurrentRequests.ValueInt64())))
+	}
+
+	// The per-status delays below override the shared base delay
+	if !config.RetryBaseDelayMs.IsNull() {
+		delay := time.Duration(config.RetryBaseDelayMs.ValueInt64()) * time.Millisecond
+		opts = append(opts,
+			WithRetryBaseDelay(http.StatusTooManyRequests, delay),
+			WithRetryBaseDelay(http.StatusServiceUnavailable, delay))
+	}
+	if !config.RateLimitRetryDelay.IsNull() {
+		opts = append(opts, WithRetryBaseDelay(http.StatusTooManyRequests, time.Duration(config.RateLimitRetryDelay.ValueInt64())*time.Second))
+	}
+	if !config.UnavailableRetryDelay.IsNull() {
+		opts = append(opts, WithRetryBaseDelay(http.StatusServiceUnavailable, time.Duration(config.UnavailableRetryDelay.ValueInt64())*time.Second))
+	}
+
+	if !config.MaxTotalCostPerHr.IsNull() {
+		opts = append(opts, WithMaxTotalCostPerHr(config.MaxTotalCostPerHr.ValueFloat64()))
+	}
+
+	if !config.DebugLogMaxBytes.IsNull() {
+		opts = append(opts, WithDebugLogMaxBytes(int(config.DebugLogMaxBytes.ValueInt64())))
+	}
+
+	if !config.RequestHeaders.IsNull() {
+		headers := make(map[string]string)
+		resp.Diagnostics.Append(config.RequestHeaders.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for name := range headers {
+			if reservedHeaders[http.CanonicalHeaderKey(name)] {
+				resp.Diagnostics.AddAttributeWarning(path.Root("request_headers"),
+					"Reserved Request Header Ignored",
+					fmt.Sprintf("The %s header is set by the provider and cannot be overridden.", name))
+			}
+		}
+		opts = append(opts, WithHeaders(headers))
+	}
+
+	if config.StrictPortLimit.ValueBool() {
+		opts = append(opts, WithStrictPortLimit())
+	}
+
+	if !config.GraphQLWarningPatterns.IsNull() {
+		var patterns []string
+		resp.Diagnostics.Append(config.GraphQLWarningPatterns.ElementsAs(ctx, &patterns, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		compiled := make([]*regexp.Regexp, 0, len(patterns))
+		for i, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("graphql_warning_patterns").AtListIndex(i),
+					"Invalid GraphQL Warning Pattern", err.Error())
+				return
+			}
+			compiled = append(compiled, re)
+		}
+		opts = append(opts, WithGraphQLWarningPatterns(compiled))
+	}
+
+	endpoint, diags := apiEndpoint(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	opts = append(opts, WithEndpoint(endpoint))
+
 	// Create and validate client
-	client := NewClient(apiKey)
-	if err := client.Ping(); err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create RunPod API Client",
-			"Error: "+err.Error(),
-		)
+	client := NewClient(apiKey, opts...)
+	resp.Diagnostics.Append(checkConnection(ctx, client)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -94,5 +315,116 @@ func (p *RunpodProvider) Resources(ctx context.Context) []func() resource.Resour
 func (p *RunpodProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewGpuTypesDataSource,
+		NewNetworkVolumeDataSource,
+		NewPodsMetricsDataSource,
+		NewDebugQueriesDataSource,
+		NewPodValidationDataSource,
+		NewPodDataSource,
+		NewPodsDataSource,
+		NewAccountDataSource,
+	}
+}
+
+// appendGraphQLWarnings reports the GraphQL errors the client treated as
+// warnings while serving a resource or data source operation
+func appendGraphQLWarnings(diags *diag.Diagnostics, client *Client) {
+	if client == nil {
+		return
+	}
+	for _, message := range client.TakeWarnings() {
+		diags.AddWarning("RunPod API Warning", message)
+	}
+}
+
+// apiEndpoint returns the GraphQL endpoint from base_url, or RUNPOD_API_URL,
+// and graphql_path. The URL may be the full endpoint, such as
+// https://api.runpod.io/graphql, in which case its path is the GraphQL path.
+func apiEndpoint(config RunpodProviderModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	apiURL := defaultAPIHost
+	if env := os.Getenv("RUNPOD_API_URL"); env != "" {
+		apiURL = env
+	}
+	if !config.BaseURL.IsNull() {
+		apiURL = config.BaseURL.ValueString()
 	}
+	host, urlPath := splitAPIURL(apiURL)
+	if _, err := graphQLEndpoint(host, defaultGraphQLPath); err != nil {
+		if config.BaseURL.IsNull() {
+			diags.AddError("Invalid API URL", "The RUNPOD_API_URL environment variable is invalid: "+err.Error())
+		} else {
+			diags.AddAttributeError(path.Root("base_url"), "Invalid API URL", err.Error())
+		}
+		return "", diags
+	}
+
+	graphQLPath := defaultGraphQLPath
+	if urlPath != "" {
+		graphQLPath = urlPath
+	}
+	if !config.GraphQLPath.IsNull() {
+		if urlPath != "" && urlPath != config.GraphQLPath.ValueString() {
+			diags.AddAttributeError(path.Root("graphql_path"), "Conflicting GraphQL Path",
+				fmt.Sprintf("graphql_path is %q but the API URL %q already includes the path %q. Set one or the other.",
+					config.GraphQLPath.ValueString(), apiURL, urlPath))
+			return "", diags
+		}
+		graphQLPath = config.GraphQLPath.ValueString()
+	}
+	endpoint, err := graphQLEndpoint(host, graphQLPath)
+	if err != nil {
+		diags.AddAttributeError(path.Root("graphql_path"), "Invalid GraphQL Path", err.Error())
+		return "", diags
+	}
+	return endpoint, diags
+}
+
+// splitAPIURL splits an API URL into its host and its path, which is empty
+// for a host-only URL. A URL that does not parse is returned whole for
+// graphQLEndpoint to report.
+func splitAPIURL(apiURL string) (string, string) {
+	u, err := url.Parse(apiURL)
+	if err != nil || strings.Trim(u.Path, "/") == "" {
+		return apiURL, ""
+	}
+	urlPath := u.Path
+	u.Path, u.RawPath = "", ""
+	return u.String(), urlPath
+}
+
+// checkConnection pings the API, giving up after pingTimeout so that a RunPod
+// outage fails fast instead of hanging plan
+func checkConnection(ctx context.Context, client *Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	err := client.Ping(ctx)
+	if err == nil {
+		return diags
+	}
+
+	var httpErr *HTTPError
+	switch {
+	case errors.As(err, &httpErr) && (httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden):
+		diags.AddError(
+			"RunPod Authentication Failed",
+			"The RunPod API rejected the API key. Check the api_key value or the RUNPOD_API_KEY environment variable.\n\nError: "+err.Error(),
+		)
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &httpErr) && (httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500):
+		diags.AddError(
+			"RunPod API Temporarily Unavailable",
+			"The RunPod API could not be reached after retrying. This is usually transient; try again shortly.\n\nError: "+err.Error(),
+		)
+	default:
+		diags.AddError(
+			"Unable to Create RunPod API Client",
+			"Error: "+err.Error(),
+		)
+	}
+
+	return diags
 }
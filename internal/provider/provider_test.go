@@ -1,10 +1,17 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
@@ -17,3 +24,141 @@ func testAccPreCheck(t *testing.T) {
 		t.Skip("RUNPOD_API_KEY must be set for acceptance tests")
 	}
 }
+
+// newTestClient returns a Client that sends its requests to a stub API server
+func newTestClient(t testing.TB, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient("test-key")
+	client.baseURL = server.URL
+	for status := range client.retryDelays {
+		client.retryDelays[status] = time.Millisecond
+	}
+	return client
+}
+
+// graphQLDoerFunc adapts a function to the graphQLDoer interface
+type graphQLDoerFunc func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error)
+
+func (f graphQLDoerFunc) Do(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	return f(ctx, query, variables)
+}
+
+// newFakeClient returns a Client whose API methods are answered by doer
+// without any HTTP traffic
+func newFakeClient(doer graphQLDoerFunc) *Client {
+	client := NewClient("test-key")
+	client.gql = doer
+	return client
+}
+
+func TestCheckConnection_retriesUnavailable(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"myself":{"id":"user-1"}}}`)
+	})
+
+	diags := checkConnection(context.Background(), client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestCheckConnection_authFailed(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	diags := checkConnection(context.Background(), client)
+	if !diags.HasError() {
+		t.Fatal("expected error")
+	}
+	if summary := diags[0].Summary(); summary != "RunPod Authentication Failed" {
+		t.Errorf("unexpected summary: %s", summary)
+	}
+}
+
+func TestCheckConnection_unavailable(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	diags := checkConnection(context.Background(), client)
+	if !diags.HasError() {
+		t.Fatal("expected error")
+	}
+	if summary := diags[0].Summary(); summary != "RunPod API Temporarily Unavailable" {
+		t.Errorf("unexpected summary: %s", summary)
+	}
+}
+
+func TestAPIEndpoint(t *testing.T) {
+	t.Setenv("RUNPOD_API_URL", "")
+
+	endpoint, diags := apiEndpoint(RunpodProviderModel{BaseURL: types.StringNull(), GraphQLPath: types.StringNull()})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if endpoint != defaultBaseURL {
+		t.Errorf("expected the default endpoint, got %q", endpoint)
+	}
+
+	t.Setenv("RUNPOD_API_URL", "http://127.0.0.1:8080")
+	endpoint, _ = apiEndpoint(RunpodProviderModel{BaseURL: types.StringNull(), GraphQLPath: types.StringValue("/v2/graphql")})
+	if endpoint != "http://127.0.0.1:8080/v2/graphql" {
+		t.Errorf("expected RUNPOD_API_URL joined with graphql_path, got %q", endpoint)
+	}
+
+	endpoint, _ = apiEndpoint(RunpodProviderModel{BaseURL: types.StringValue("https://proxy.example.com"), GraphQLPath: types.StringNull()})
+	if endpoint != "https://proxy.example.com/graphql" {
+		t.Errorf("expected base_url to take precedence over RUNPOD_API_URL, got %q", endpoint)
+	}
+
+	t.Setenv("RUNPOD_API_URL", "https://api.runpod.io/graphql")
+	endpoint, diags = apiEndpoint(RunpodProviderModel{BaseURL: types.StringNull(), GraphQLPath: types.StringNull()})
+	if diags.HasError() || endpoint != "https://api.runpod.io/graphql" {
+		t.Errorf("expected a full RUNPOD_API_URL endpoint to be accepted, got %q (%v)", endpoint, diags)
+	}
+
+	endpoint, diags = apiEndpoint(RunpodProviderModel{BaseURL: types.StringValue("https://proxy.example.com/runpod/graphql"), GraphQLPath: types.StringNull()})
+	if diags.HasError() || endpoint != "https://proxy.example.com/runpod/graphql" {
+		t.Errorf("expected a full base_url endpoint to be accepted, got %q (%v)", endpoint, diags)
+	}
+
+	endpoint, diags = apiEndpoint(RunpodProviderModel{BaseURL: types.StringValue("https://api.runpod.io/graphql"), GraphQLPath: types.StringValue("/graphql")})
+	if diags.HasError() || endpoint != "https://api.runpod.io/graphql" {
+		t.Errorf("expected https://api.runpod.io/graphql with a matching graphql_path, got %q (%v)", endpoint, diags)
+	}
+
+	_, diags = apiEndpoint(RunpodProviderModel{BaseURL: types.StringValue("https://api.runpod.io/graphql"), GraphQLPath: types.StringValue("/v2/graphql")})
+	if !diags.HasError() {
+		t.Error("expected an error for a graphql_path that conflicts with the base_url path")
+	}
+
+	_, diags = apiEndpoint(RunpodProviderModel{BaseURL: types.StringValue("https://proxy.example.com/graphql?key=1"), GraphQLPath: types.StringNull()})
+	if !diags.HasError() {
+		t.Error("expected an error for a base_url with a query")
+	}
+}
+
+// decodeGraphQLRequest parses the GraphQL request sent to a stub API server
+func decodeGraphQLRequest(t testing.TB, r *http.Request) graphQLRequest {
+	t.Helper()
+
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		t.Fatalf("failed to decode request: %s", err)
+	}
+	return req
+}